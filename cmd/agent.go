@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"benchmark/internal/agent"
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+func init() {
+	RegisterCommand(newAgentCommand())
+}
+
+func newAgentCommand() *Command {
+	flags := flag.NewFlagSet("agent", flag.ExitOnError)
+
+	config := struct {
+		Listen       string
+		Dir          string
+		MetricsAddr  string
+		OTLPEndpoint string
+		TraceFile    string
+	}{
+		Listen: "127.0.0.1:7070",
+	}
+
+	flags.StringVar(&config.Listen, "listen", config.Listen, "Address the agent listens on for a coordinator")
+	flags.StringVar(&config.MetricsAddr, "metrics-addr", config.MetricsAddr, "Address to serve Prometheus metrics of the requests of the agent's threads on, empty serves none")
+	flags.StringVar(&config.OTLPEndpoint, "otlp-endpoint", config.OTLPEndpoint, "OTLP/HTTP endpoint to export the spans of the agent's threads to, as host:port or URL")
+	flags.StringVar(&config.TraceFile, "trace-file", config.TraceFile, "File to write the spans of the agent's threads to, one JSON span per line")
+	flags.StringVar(&config.Dir, "dir", config.Dir, "Directory of the per-thread logs until the coordinator collects them, by default one per listen address")
+
+	return &Command{
+		Name:        "agent",
+		Description: "Run the worker threads that a benchmark coordinator distributes with -agents",
+		Flags:       flags,
+		Handler: func() error {
+			// Agents on the same host keep their logs apart
+			dir := config.Dir
+			if dir == "" {
+				dir = filepath.Join("./output/agents", strings.NewReplacer(":", "_", "[", "", "]", "").Replace(config.Listen))
+			}
+
+			agentServer := agent.NewServer(dir, setupCatalog)
+			metrics, err := serveMetrics(config.MetricsAddr)
+			if err != nil {
+				return err
+			}
+			agentServer.Metrics = metrics
+			stopTracing, err := setupTracing(config.OTLPEndpoint, config.TraceFile)
+			if err != nil {
+				return err
+			}
+			defer stopTracing()
+			server := &http.Server{Addr: config.Listen, Handler: agentServer.Handler()}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				server.Close()
+			}()
+
+			log.Printf("Agent listening on %s", config.Listen)
+			if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+}
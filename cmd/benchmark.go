@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"benchmark/internal"
+	"benchmark/internal/agent"
 	"benchmark/internal/catalog/polaris"
 	"benchmark/internal/catalog/unity"
 	"benchmark/internal/common"
@@ -12,9 +13,11 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"log"
+	"math/rand/v2"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -29,12 +32,35 @@ func newBenchmarkCommand() *Command {
 
 	// Anonymous flag config struct
 	config := struct {
-		ExperimentID uuid.UUID
-		BenchmarkID  int
-		Catalog      string
-		Threads      int
-		Entity       string
-		Duration     string
+		ExperimentID   uuid.UUID
+		BenchmarkID    int
+		Catalog        string
+		Threads        int
+		Entity         string
+		Duration       string
+		Parent         string
+		Populate       int
+		PageSize       int
+		Depth          int
+		FanOut         int
+		Principals     int
+		PrincipalRoles bool
+		Payload        string
+		PopulateTree   string
+		Manifest       string
+		Keys           int
+		Distribution   string
+		Seed           uint64
+		ThinkTime      string
+		Rate           float64
+		Agents         string
+		Dashboard      bool
+		MetricsAddr    string
+		OTLPEndpoint   string
+		Summary        bool
+		BodySample     float64
+		Parquet        bool
+		TraceFile      string
 	}{
 		// Default values
 		ExperimentID: uuid.New(),
@@ -43,6 +69,17 @@ func newBenchmarkCommand() *Command {
 		Threads:      1,
 		Entity:       "catalog",
 		Duration:     "10s",
+		Parent:       "schema",
+		Populate:     1000,
+		PageSize:     10,
+		Depth:        3,
+		FanOut:       3,
+		Manifest:     "./output/manifests/populate.jsonl",
+		Keys:         100,
+		Distribution: common.UniformDistribution,
+		BodySample:   0.01,
+		// Principals without roles cannot access the benchmark catalogs
+		PrincipalRoles: true,
 	}
 
 	flags.IntVar(&config.BenchmarkID, "benchmark-id", config.BenchmarkID, "Benchmark ID")
@@ -50,6 +87,29 @@ func newBenchmarkCommand() *Command {
 	flags.IntVar(&config.Threads, "threads", config.Threads, "Threads")
 	flags.StringVar(&config.Entity, "entity", config.Entity, "Entity")
 	flags.StringVar(&config.Duration, "duration", config.Duration, "Duration")
+	flags.StringVar(&config.Parent, "parent", config.Parent, "Parent entity that is deleted and re-created in the parent/child benchmark (schema or catalog)")
+	flags.IntVar(&config.Populate, "populate", config.Populate, "Number of entities created before the pagination benchmark")
+	flags.IntVar(&config.PageSize, "page-size", config.PageSize, "Page size of the pagination benchmark")
+	flags.IntVar(&config.Depth, "depth", config.Depth, "Depth of the namespace trees in the namespace tree benchmark")
+	flags.IntVar(&config.FanOut, "fan-out", config.FanOut, "Children per namespace in the namespace tree benchmark")
+	flags.StringVar(&config.Payload, "payload", config.Payload, "Payload of created and updated entities as key=value pairs with a number or min-max range, e.g. columns=10-100,nesting=2,properties=0-50,property-size=64,comment=0-1000,representations=1-5")
+	flags.StringVar(&config.PopulateTree, "populate-tree", config.PopulateTree, "Catalogs x schemas x tables to populate before the benchmark, e.g. 50x100x1000")
+	flags.StringVar(&config.Manifest, "manifest", config.Manifest, "Manifest of the populated entities, which is resumed if it exists")
+	flags.IntVar(&config.Keys, "keys", config.Keys, "Number of entities in the pool of the skewed update benchmark")
+	flags.StringVar(&config.Distribution, "distribution", config.Distribution, "Distribution of the keys chosen from the pool: uniform, zipfian[:theta], hotspot[:ops:keys] or latest[:theta], which favors the most recently written keys")
+	flags.Uint64Var(&config.Seed, "seed", config.Seed, "Seed of the per-thread random choices, 0 draws a new seed that is stored with the experiment")
+	flags.StringVar(&config.ThinkTime, "think-time", config.ThinkTime, "Think time of every thread between iterations: fixed:duration, uniform:min-max or exponential:mean")
+	flags.Float64Var(&config.Rate, "rate", config.Rate, "Target iterations per second of every thread, 0 runs the iterations back to back")
+	flags.BoolVar(&config.Summary, "summary", config.Summary, "Keep per-thread latency histograms and status counts, and log the response bodies only for errors, a sample and the consistency checks")
+	flags.Float64Var(&config.BodySample, "body-sample", config.BodySample, "Fraction of the successful responses logged with their body in summary mode")
+	flags.BoolVar(&config.Parquet, "parquet", config.Parquet, "Write the merged log of the experiment to output/parquet as well, with the experiment in the file metadata")
+	flags.StringVar(&config.OTLPEndpoint, "otlp-endpoint", config.OTLPEndpoint, "OTLP/HTTP endpoint to export the spans of the operations and requests to, as host:port or URL")
+	flags.StringVar(&config.TraceFile, "trace-file", config.TraceFile, "File to write the spans of the operations and requests to, one JSON span per line")
+	flags.StringVar(&config.Agents, "agents", config.Agents, "Comma-separated host:port of the agents the threads are distributed across, empty runs all threads in this process")
+	flags.BoolVar(&config.Dashboard, "dashboard", config.Dashboard, "Show live statistics of the threads in the terminal, refreshed every second, with the last lines of the log below (not with -agents)")
+	flags.StringVar(&config.MetricsAddr, "metrics-addr", config.MetricsAddr, "Address to serve Prometheus metrics of the requests on, e.g. :9090, empty serves none")
+	flags.IntVar(&config.Principals, "principals", config.Principals, "Number of principals the threads run as, 0 runs all threads with the root credentials")
+	flags.BoolVar(&config.PrincipalRoles, "principal-roles", config.PrincipalRoles, "Grant every principal its own principal role with catalog_admin on the catalogs of the setup, false leaves the principals without privileges")
 
 	return &Command{
 		Name:        "benchmark",
@@ -64,19 +124,92 @@ func newBenchmarkCommand() *Command {
 			}
 
 			experiment := common.Experiment{
-				ID:          config.ExperimentID,
-				BenchmarkID: benchmarkType,
-				Catalog:     config.Catalog,
-				Threads:     config.Threads,
-				Duration:    duration,
-				Entity:      entityType,
+				ID:             config.ExperimentID,
+				BenchmarkID:    benchmarkType,
+				Catalog:        config.Catalog,
+				Threads:        config.Threads,
+				Seed:           config.Seed,
+				Rate:           config.Rate,
+				Duration:       duration,
+				Entity:         entityType,
+				Principals:     config.Principals,
+				PrincipalRoles: config.PrincipalRoles && config.Principals > 0,
 			}
-			return runBenchmark(experiment)
+			if config.Summary {
+				experiment.Summary = true
+				experiment.BodySample = config.BodySample
+			}
+			if config.Payload != "" {
+				payload, err := common.ParsePayload(config.Payload)
+				if err != nil {
+					log.Fatal(err)
+				}
+				experiment.Payload = &payload
+			}
+			if config.Agents != "" {
+				if config.Dashboard {
+					log.Fatal("-dashboard counts the threads of this process only and cannot be combined with -agents")
+				}
+				experiment.Agents = strings.Split(config.Agents, ",")
+			}
+			if config.ThinkTime != "" {
+				thinkTime, err := common.ParseThinkTime(config.ThinkTime)
+				if err != nil {
+					log.Fatal(err)
+				}
+				experiment.ThinkTime = &thinkTime
+			}
+			if config.PopulateTree != "" {
+				tree, err := common.ParseTree(config.PopulateTree)
+				if err != nil {
+					log.Fatal(err)
+				}
+				experiment.PopulateTree = &tree
+			}
+			if config.PopulateTree != "" || benchmarkType == common.ManifestReadBenchmark {
+				experiment.Manifest = config.Manifest
+			}
+			if benchmarkType == common.ParentChildBenchmark {
+				experiment.Parent = common.EntityType(config.Parent)
+			}
+			if benchmarkType == common.PaginationBenchmark {
+				experiment.Populate = config.Populate
+				experiment.PageSize = config.PageSize
+			}
+			if benchmarkType == common.NamespaceTreeBenchmark {
+				experiment.Depth = config.Depth
+				experiment.FanOut = config.FanOut
+			}
+			if benchmarkType == common.SkewedUpdateBenchmark {
+				distribution, err := common.ParseDistribution(config.Distribution)
+				if err != nil {
+					log.Fatal(err)
+				}
+				experiment.Keys = config.Keys
+				experiment.Distribution = &distribution
+			}
+			metrics, err := serveMetrics(config.MetricsAddr)
+			if err != nil {
+				log.Fatal(err)
+			}
+			stopTracing, err := setupTracing(config.OTLPEndpoint, config.TraceFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer stopTracing()
+			return runBenchmark(experiment, runOptions{Dashboard: config.Dashboard, Metrics: metrics, Parquet: config.Parquet})
 		},
 	}
 }
 
-func runBenchmark(experiment common.Experiment) error {
+// runOptions change how an experiment runs without being part of it.
+type runOptions struct {
+	Dashboard bool              // Redraws the live statistics of the threads every second
+	Metrics   *internal.Metrics // Counts the requests of the threads for Prometheus, if set
+	Parquet   bool              // Writes the merged log as a Parquet file as well
+}
+
+func runBenchmark(experiment common.Experiment, options runOptions) error {
 	log.Printf("Starting experiment %s with benchmark scenario %d on entity %s", experiment.ID, experiment.BenchmarkID, experiment.Entity)
 
 	// Setup the catalog
@@ -95,9 +228,32 @@ func runBenchmark(experiment common.Experiment) error {
 	// Setup the benchmark engine
 	engine := internal.NewBenchmarkEngine(experiment.ID.String(), catalog, experiment.Threads, experiment.Duration)
 
+	if experiment.Seed == 0 {
+		experiment.Seed = rand.Uint64()
+	}
+	engine.Seed = experiment.Seed
+	engine.Entity = experiment.Entity
+	engine.Summary = experiment.Summary
+	engine.BodySample = experiment.BodySample
+	log.Printf("Using seed %d", experiment.Seed)
+
+	stopDashboard := func() {}
+	if options.Dashboard {
+		engine.Monitor = internal.NewMonitor()
+		title := fmt.Sprintf("Experiment %s: benchmark %d on %s %s with %d threads", experiment.ID, experiment.BenchmarkID, experiment.Catalog, experiment.Entity, experiment.Threads)
+		stopDashboard = startDashboard(ctx, engine.Monitor, title)
+	}
+	defer stopDashboard()
+	if options.Metrics != nil {
+		engine.Metrics = options.Metrics.For(experiment.Catalog, string(experiment.Entity))
+	}
+
 	// Set start time
 	startTime := time.Now()
 	experiment.StartTimestamp = startTime
+	// The logs measure monotonic elapsed times from the same instant
+	experiment.Anchor = startTime.UTC()
+	engine.Anchor = startTime
 
 	// Clean up logs directory
 	defer func() {
@@ -107,13 +263,47 @@ func runBenchmark(experiment common.Experiment) error {
 		}
 	}()
 
-	workers, err := setupWorkers(ctx, experiment, catalog)
+	if experiment.PopulateTree != nil {
+		_, err = setup.Populate(ctx, catalog, *experiment.PopulateTree, setup.PopulateThreads, experiment.Manifest)
+		if err != nil {
+			return fmt.Errorf("failed to populate catalog: %v", err)
+		}
+	}
+
+	// The setup names its entities from a stream of the seed as well
+	names := common.NewNames(common.SeededRand(experiment.Seed, common.SetupStream), experiment.ID.String())
+	workers, err := setupWorkers(ctx, experiment, catalog, names)
 	if err != nil {
 		return err
 	}
 
+	if experiment.Principals > 0 {
+		engine.Principals, err = setup.CreatePrincipals(ctx, catalog, names, experiment.Principals, experiment.PrincipalRoles, workers)
+		if err != nil {
+			return fmt.Errorf("failed to create principals: %v", err)
+		}
+	}
+
+	if len(experiment.Agents) > 0 {
+		experiment.Clocks, err = agent.MeasureClocks(ctx, experiment.Agents)
+		if err != nil {
+			return fmt.Errorf("failed to measure agent clocks: %v", err)
+		}
+		for _, clock := range experiment.Clocks {
+			log.Printf("Agent %s clock offset %s ± %s", clock.Agent, clock.Offset, clock.Uncertainty)
+		}
+	}
+
 	go func(workers []internal.WorkerConfig) {
-		if err = engine.RunBenchmark(ctx, workers); err != nil {
+		run := engine.RunBenchmark
+		if len(experiment.Agents) > 0 {
+			run = func(ctx context.Context, workers []internal.WorkerConfig) error {
+				return runAgents(ctx, engine, experiment, workers)
+			}
+		}
+		err = run(ctx, workers)
+		stopDashboard()
+		if err != nil {
 			log.Printf("Error running benchmark: %s", err)
 			done <- err
 			return
@@ -125,8 +315,40 @@ func runBenchmark(experiment common.Experiment) error {
 
 	go handleShutdownSignal(quit, done, cancel, experiment)
 
-	return processResults(done, startTime, experiment)
+	return processResults(done, startTime, experiment, options)
+
+}
+
+// startDashboard redraws the dashboard until the returned function is called.
+// Meanwhile the log is shown below the statistics, as every redraw clears the
+// terminal.
+func startDashboard(ctx context.Context, monitor *internal.Monitor, title string) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	log.SetOutput(monitor)
+	go func() {
+		monitor.Dashboard(ctx, title, os.Stdout)
+		close(stopped)
+	}()
 
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-stopped
+			log.SetOutput(os.Stderr)
+		})
+	}
+}
+
+// runAgents runs the threads on the agents of the experiment and the audits
+// in this process, which holds the state of the setup.
+func runAgents(ctx context.Context, engine *internal.BenchmarkEngine, experiment common.Experiment, workers []internal.WorkerConfig) error {
+	threads, err := agent.Run(ctx, experiment, workers, engine.Principals, experiment.Agents, engine.LogDir)
+	if err != nil {
+		return err
+	}
+	return engine.RunAudits(ctx, workers, threads)
 }
 
 func saveExperiment(experiment common.Experiment, dir string) error {
@@ -154,6 +376,30 @@ func saveExperiment(experiment common.Experiment, dir string) error {
 	return nil
 }
 
+// saveSummary merges the summaries of the threads from the merged log, logs
+// the latency and status codes by method and saves them in dir.
+func saveSummary(experiment common.Experiment, dir string) error {
+	summary, err := common.MergeSummaries(filepath.Join("./output/logs", fmt.Sprintf("%s.jsonl", experiment.ID)))
+	if err != nil {
+		return err
+	}
+
+	methods := summary.Methods()
+	for _, method := range methods {
+		log.Printf("%-6s %8d requests, p50 %.2fms, p90 %.2fms, p99 %.2fms, p99.9 %.2fms, max %.2fms, status codes %v",
+			method.Method, method.Requests, method.P50Ms, method.P90Ms, method.P99Ms, method.P999Ms, method.MaxMs, method.Statuses)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"experiment_id": experiment.ID, "methods": methods}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, fmt.Sprintf("%s.json", experiment.ID)), data, 0644)
+}
+
 func handleShutdownSignal(quit chan os.Signal, done chan error, cancel context.CancelFunc, experiment common.Experiment) {
 	sig := <-quit
 	log.Printf("Received signal %q, shutting down...", sig)
@@ -178,8 +424,8 @@ func setupCatalog(catalog string) (internal.Catalog, error) {
 	}
 }
 
-func setupWorkers(ctx context.Context, experiment common.Experiment, catalog internal.Catalog) ([]internal.WorkerConfig, error) {
-	var benchmarkMap map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error)
+func setupWorkers(ctx context.Context, experiment common.Experiment, catalog internal.Catalog, names *common.Names) ([]internal.WorkerConfig, error) {
+	var benchmarkMap map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error)
 	switch experiment.BenchmarkID {
 	case common.CreateBenchmark:
 		benchmarkMap = createBenchmarkMap()
@@ -191,6 +437,34 @@ func setupWorkers(ctx context.Context, experiment common.Experiment, catalog int
 		benchmarkMap = createDeleteListBenchmarkMap()
 	case common.UpdateGetBenchmark:
 		benchmarkMap = updateGetBenchmarkMap()
+	case common.ConflictUpdateBenchmark:
+		benchmarkMap = conflictUpdateBenchmarkMap()
+	case common.PropertyUpdateBenchmark:
+		benchmarkMap = propertyUpdateBenchmarkMap()
+	case common.RaceCreateBenchmark:
+		benchmarkMap = raceCreateBenchmarkMap()
+	case common.ParentChildBenchmark:
+		benchmarkMap = parentChildBenchmarkMap()
+	case common.RecreateBenchmark:
+		benchmarkMap = recreateBenchmarkMap()
+	case common.PaginationBenchmark:
+		benchmarkMap = paginationBenchmarkMap(experiment.Populate, experiment.PageSize)
+	case common.CommitTableBenchmark:
+		benchmarkMap = commitTableBenchmarkMap()
+	case common.TransactionBenchmark:
+		benchmarkMap = transactionBenchmarkMap()
+	case common.ViewVersionBenchmark:
+		benchmarkMap = viewVersionBenchmarkMap()
+	case common.ModelVersionBenchmark:
+		benchmarkMap = modelVersionBenchmarkMap()
+	case common.PermissionBenchmark:
+		benchmarkMap = permissionBenchmarkMap()
+	case common.NamespaceTreeBenchmark:
+		benchmarkMap = namespaceTreeBenchmarkMap(experiment.Depth, experiment.FanOut)
+	case common.ManifestReadBenchmark:
+		benchmarkMap = manifestReadBenchmarkMap(experiment.Manifest)
+	case common.SkewedUpdateBenchmark:
+		benchmarkMap = skewedUpdateBenchmarkMap(experiment.Keys, *experiment.Distribution)
 
 	default:
 		return nil, fmt.Errorf("unsupported benchmark type %d", experiment.BenchmarkID)
@@ -201,15 +475,24 @@ func setupWorkers(ctx context.Context, experiment common.Experiment, catalog int
 		return nil, fmt.Errorf("unsupported entity type %s for benchmark %d", experiment.Entity, experiment.BenchmarkID)
 	}
 
-	workers, err := workerFunc(ctx, catalog, experiment.Threads)
+	workers, err := workerFunc(ctx, catalog, names, experiment.Threads)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup workers: %v", err)
 	}
 
+	// Experiment options only fill in what the setup did not decide itself
+	for _, worker := range workers {
+		for k, v := range experiment.WorkerParams() {
+			if _, exists := worker.Params[k]; !exists {
+				worker.Params[k] = v
+			}
+		}
+	}
+
 	return workers, nil
 }
 
-func processResults(done chan error, startTime time.Time, experiment common.Experiment) error {
+func processResults(done chan error, startTime time.Time, experiment common.Experiment, options runOptions) error {
 	err := <-done
 	if err != nil {
 		return err
@@ -226,11 +509,21 @@ func processResults(done chan error, startTime time.Time, experiment common.Expe
 	go func() {
 		defer wg.Done()
 		log.Printf("Merging logs...")
-		if err := common.MergeLogs("./output/logs/tmp", experiment.ID.String()); err != nil {
+		var parquetOutput *common.ParquetOutput
+		if options.Parquet {
+			parquetOutput = &common.ParquetOutput{Dir: "./output/parquet", Experiment: experiment}
+		}
+		if err := common.MergeLogs("./output/logs/tmp", experiment.ID.String(), parquetOutput); err != nil {
 			log.Printf("Error merging logs: %s", err)
 		}
 		log.Printf("Logs merged")
 
+		if experiment.Summary {
+			if err := saveSummary(experiment, "./output/summaries"); err != nil {
+				log.Printf("Error summarizing logs: %s", err)
+			}
+		}
+
 		log.Printf("Saving experiment...")
 		if err := saveExperiment(experiment, "./output/experiments"); err != nil {
 			log.Printf("Error saving experiment: %s", err)
@@ -243,12 +536,12 @@ func processResults(done chan error, startTime time.Time, experiment common.Expe
 	return nil
 }
 
-func createBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error){
-		common.CatalogEntity: func(_ context.Context, _ internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func createBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity: func(_ context.Context, _ internal.Catalog, _ *common.Names, threads int) ([]internal.WorkerConfig, error) {
 			return setup.CreateCatalog(threads)
 		},
-		common.PrincipalEntity: func(_ context.Context, _ internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+		common.PrincipalEntity: func(_ context.Context, _ internal.Catalog, _ *common.Names, threads int) ([]internal.WorkerConfig, error) {
 			return setup.CreatePrincipal(threads)
 		},
 		common.SchemaEntity:   setup.CreateSchema,
@@ -260,8 +553,8 @@ func createBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalo
 	}
 }
 
-func createDeleteBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error){
+func createDeleteBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
 		common.CatalogEntity:   setup.CreateDeleteCatalog,
 		common.PrincipalEntity: setup.CreateDeletePrincipal,
 		common.SchemaEntity:    setup.CreateDeleteSchema,
@@ -273,8 +566,8 @@ func createDeleteBenchmarkMap() map[common.EntityType]func(ctx context.Context,
 	}
 }
 
-func updateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error){
+func updateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
 		common.CatalogEntity:   setup.UpdateCatalog,
 		common.PrincipalEntity: setup.UpdatePrincipal,
 		common.SchemaEntity:    setup.UpdateSchema,
@@ -285,8 +578,8 @@ func updateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalo
 	}
 }
 
-func createDeleteListBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error){
+func createDeleteListBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
 		common.CatalogEntity:   setup.CreateDeleteListCatalog,
 		common.PrincipalEntity: setup.CreateDeleteListPrincipal,
 		common.SchemaEntity:    setup.CreateDeleteListSchema,
@@ -298,8 +591,8 @@ func createDeleteListBenchmarkMap() map[common.EntityType]func(ctx context.Conte
 	}
 }
 
-func updateGetBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error){
+func updateGetBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
 		common.CatalogEntity:   setup.UpdateGetCatalog,
 		common.PrincipalEntity: setup.UpdateGetPrincipal,
 		common.SchemaEntity:    setup.UpdateGetSchema,
@@ -309,3 +602,141 @@ func updateGetBenchmarkMap() map[common.EntityType]func(ctx context.Context, cat
 		common.VolumeEntity:    setup.UpdateGetVolume,
 	}
 }
+
+func conflictUpdateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity:   setup.ConflictUpdateCatalog,
+		common.PrincipalEntity: setup.ConflictUpdatePrincipal,
+	}
+}
+
+func propertyUpdateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity: setup.PropertyUpdateCatalog,
+		common.SchemaEntity:  setup.PropertyUpdateSchema,
+		common.TableEntity:   setup.PropertyUpdateTable,
+	}
+}
+
+func raceCreateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity:   setup.RaceCreateCatalog,
+		common.PrincipalEntity: setup.RaceCreatePrincipal,
+		common.SchemaEntity:    setup.RaceCreateSchema,
+		common.TableEntity:     setup.RaceCreateTable,
+	}
+}
+
+func parentChildBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.TableEntity:    setup.ParentChildTable,
+		common.ViewEntity:     setup.ParentChildView,
+		common.FunctionEntity: setup.ParentChildFunction,
+		common.VolumeEntity:   setup.ParentChildVolume,
+	}
+}
+
+func recreateBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity:   setup.RecreateCatalog,
+		common.PrincipalEntity: setup.RecreatePrincipal,
+		common.SchemaEntity:    setup.RecreateSchema,
+		common.TableEntity:     setup.RecreateTable,
+		common.ViewEntity:      setup.RecreateView,
+		common.FunctionEntity:  setup.RecreateFunction,
+		common.ModelEntity:     setup.RecreateModel,
+		common.VolumeEntity:    setup.RecreateVolume,
+	}
+}
+
+func paginationBenchmarkMap(count int, pageSize int) map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	pagination := func(setupFunc func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error)) func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+		return func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+			return setupFunc(ctx, catalog, names, threads, count, pageSize)
+		}
+	}
+
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity:  pagination(setup.PaginationCatalog),
+		common.SchemaEntity:   pagination(setup.PaginationSchema),
+		common.TableEntity:    pagination(setup.PaginationTable),
+		common.ViewEntity:     pagination(setup.PaginationView),
+		common.FunctionEntity: pagination(setup.PaginationFunction),
+		common.ModelEntity:    pagination(setup.PaginationModel),
+		common.VolumeEntity:   pagination(setup.PaginationVolume),
+	}
+}
+
+func commitTableBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.TableEntity: setup.CommitTable,
+	}
+}
+
+func transactionBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.TableEntity: setup.TransactionTable,
+	}
+}
+
+func viewVersionBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.ViewEntity: setup.ViewVersion,
+	}
+}
+
+func modelVersionBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.ModelEntity: setup.ModelVersion,
+	}
+}
+
+func permissionBenchmarkMap() map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.TableEntity: setup.PermissionChurnTable,
+	}
+}
+
+func namespaceTreeBenchmarkMap(depth int, fanOut int) map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.SchemaEntity: func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+			return setup.NamespaceTree(ctx, catalog, names, threads, depth, fanOut)
+		},
+	}
+}
+
+func manifestReadBenchmarkMap(manifestPath string) map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	manifestRead := func(setupFunc func(ctx context.Context, catalog internal.Catalog, threads int, manifest *internal.Manifest) ([]internal.WorkerConfig, error)) func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+		return func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+			manifest, err := internal.LoadManifest(manifestPath)
+			if err != nil {
+				return nil, err
+			}
+			return setupFunc(ctx, catalog, threads, manifest)
+		}
+	}
+
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity: manifestRead(setup.ManifestReadCatalog),
+		common.SchemaEntity:  manifestRead(setup.ManifestReadSchema),
+		common.TableEntity:   manifestRead(setup.ManifestReadTable),
+	}
+}
+
+func skewedUpdateBenchmarkMap(keys int, distribution common.Distribution) map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	skewed := func(setupFunc func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error)) func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+		return func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+			return setupFunc(ctx, catalog, names, threads, keys, distribution)
+		}
+	}
+
+	return map[common.EntityType]func(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error){
+		common.CatalogEntity:   skewed(setup.SkewedUpdateCatalog),
+		common.PrincipalEntity: skewed(setup.SkewedUpdatePrincipal),
+		common.SchemaEntity:    skewed(setup.SkewedUpdateSchema),
+		common.TableEntity:     skewed(setup.SkewedUpdateTable),
+		common.ViewEntity:      skewed(setup.SkewedUpdateView),
+		common.ModelEntity:     skewed(setup.SkewedUpdateModel),
+		common.VolumeEntity:    skewed(setup.SkewedUpdateVolume),
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/catalog/polaris"
+	"benchmark/internal/catalog/unity"
+	"benchmark/internal/common"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSetupWorkersResolve runs every setup against a stub catalog and checks
+// that every worker function it returns can be sent to an agent by name.
+func TestSetupWorkersResolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"credentials":{"clientId":"id","clientSecret":"secret"},"access_token":"token","entityVersion":1}`))
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	t.Setenv("POLARIS_HOST", host)
+	polarisHost, unityHost := polaris.Host, unity.Host
+	polaris.Host, unity.Host = host, host
+	defer func() { polaris.Host, unity.Host = polarisHost, unityHost }()
+
+	manifest := filepath.Join(t.TempDir(), "manifest.jsonl")
+	err := os.WriteFile(manifest, []byte(`{"catalog":"c"}`+"\n"+`{"catalog":"c","schema":"s"}`+"\n"+`{"catalog":"c","schema":"s","table":"t"}`+"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalogs := map[string]internal.Catalog{"polaris": &polaris.Catalog{}, "unity": &unity.Catalog{}}
+	entities := []common.EntityType{common.CatalogEntity, common.PrincipalEntity, common.SchemaEntity, common.TableEntity,
+		common.ViewEntity, common.FunctionEntity, common.ModelEntity, common.VolumeEntity}
+
+	for catalogName, catalog := range catalogs {
+		for benchmark := common.CreateBenchmark; benchmark <= common.SkewedUpdateBenchmark; benchmark++ {
+			for _, entity := range entities {
+				experiment := common.Experiment{
+					Catalog:      catalogName,
+					BenchmarkID:  benchmark,
+					Threads:      4,
+					Entity:       entity,
+					Parent:       common.SchemaEntity,
+					Populate:     3,
+					PageSize:     2,
+					Depth:        2,
+					FanOut:       2,
+					Manifest:     manifest,
+					Keys:         3,
+					Distribution: &common.Distribution{Type: common.UniformDistribution},
+				}
+
+				workers, err := setupWorkers(context.Background(), experiment, catalog, common.NewNames(common.SeededRand(1, common.SetupStream), ""))
+				if err != nil {
+					// Combinations the catalog or benchmark does not support are rejected by the setup
+					if strings.Contains(err.Error(), "unsupported entity type") || strings.Contains(err.Error(), common.ErrNotImplemented.Error()) {
+						continue
+					}
+					t.Errorf("%s benchmark %d on %s: %v", catalogName, benchmark, entity, err)
+					continue
+				}
+
+				for _, worker := range workers {
+					name := internal.WorkerName(worker.WorkerFunc)
+					if _, exists := internal.LookupWorker(name); !exists {
+						t.Errorf("%s benchmark %d on %s: worker %s is not registered", catalogName, benchmark, entity, name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"benchmark/internal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log"
+	"net"
+	"net/http"
+)
+
+// serveMetrics serves the metrics of the requests on /metrics of the address
+// for the rest of the process, so that Prometheus can scrape a run while it
+// goes on. An empty address serves no metrics and returns nil.
+func serveMetrics(addr string) (*internal.Metrics, error) {
+	if addr == "" {
+		return nil, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	metrics := internal.NewMetrics(registry)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			log.Printf("Error serving metrics: %s", err)
+		}
+	}()
+
+	log.Printf("Serving metrics on http://%s/metrics", listener.Addr())
+	return metrics, nil
+}
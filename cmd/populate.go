@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"benchmark/internal/common"
+	"benchmark/internal/setup"
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func init() {
+	RegisterCommand(newPopulateCommand())
+}
+
+func newPopulateCommand() *Command {
+	flags := flag.NewFlagSet("populate", flag.ExitOnError)
+
+	config := struct {
+		Catalog  string
+		Tree     string
+		Threads  int
+		Manifest string
+	}{
+		Catalog:  "polaris",
+		Tree:     "50x100x1000",
+		Threads:  setup.PopulateThreads,
+		Manifest: "./output/manifests/populate.jsonl",
+	}
+
+	flags.StringVar(&config.Catalog, "catalog", config.Catalog, "Catalog")
+	flags.StringVar(&config.Tree, "tree", config.Tree, "Catalogs x schemas x tables to populate")
+	flags.IntVar(&config.Threads, "threads", config.Threads, "Threads")
+	flags.StringVar(&config.Manifest, "manifest", config.Manifest, "Manifest of the populated entities, which is resumed if it exists")
+
+	return &Command{
+		Name:        "populate",
+		Description: "Populate a catalog with a tree of entities and write their manifest",
+		Flags:       flags,
+		Handler: func() error {
+			tree, err := common.ParseTree(config.Tree)
+			if err != nil {
+				return err
+			}
+
+			catalog, err := setupCatalog(config.Catalog)
+			if err != nil {
+				return err
+			}
+
+			// Stops on a signal, the manifest keeps everything created so far
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			_, err = setup.Populate(ctx, catalog, tree, config.Threads, config.Manifest)
+			return err
+		},
+	}
+}
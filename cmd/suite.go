@@ -17,23 +17,39 @@ func init() {
 
 func newSuiteCommand() *Command {
 	var catalog string
+	var metricsAddr string
+	var otlpEndpoint string
+	var traceFile string
+	var parquet bool
 
 	flags := flag.NewFlagSet("suite", flag.ExitOnError)
 
 	flags.StringVar(&catalog, "catalog", "polaris", "Catalog to use for the suite")
+	flags.StringVar(&otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP endpoint to export the spans of all benchmarks to, as host:port or URL")
+	flags.StringVar(&traceFile, "trace-file", "", "File to write the spans of all benchmarks to, one JSON span per line")
+	flags.BoolVar(&parquet, "parquet", false, "Write the merged log of every benchmark to output/parquet as well, with the experiment in the file metadata")
+	flags.StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics of the requests of all benchmarks on, empty serves none")
 
 	return &Command{
 		Name:        "suite",
 		Description: "Run a suite of predefined benchmarks",
 		Flags:       flags,
 		Handler: func() error {
-
-			return runSuite(catalog)
+			metrics, err := serveMetrics(metricsAddr)
+			if err != nil {
+				return err
+			}
+			stopTracing, err := setupTracing(otlpEndpoint, traceFile)
+			if err != nil {
+				return err
+			}
+			defer stopTracing()
+			return runSuite(catalog, runOptions{Metrics: metrics, Parquet: parquet})
 		},
 	}
 }
 
-func runSuite(catalog string) error {
+func runSuite(catalog string, options runOptions) error {
 	entities := []common.EntityType{common.CatalogEntity, common.SchemaEntity, common.TableEntity}
 
 	catalogEntities := map[string][]common.EntityType{
@@ -53,6 +69,19 @@ func runSuite(catalog string) error {
 		common.UpdateBenchmark,
 		common.CreateDeleteListBenchmark,
 		common.UpdateGetBenchmark,
+		common.ConflictUpdateBenchmark,
+		common.PropertyUpdateBenchmark,
+		common.RaceCreateBenchmark,
+		common.ParentChildBenchmark,
+		common.RecreateBenchmark,
+		common.PaginationBenchmark,
+		common.CommitTableBenchmark,
+		common.TransactionBenchmark,
+		common.ViewVersionBenchmark,
+		common.ModelVersionBenchmark,
+		common.PermissionBenchmark,
+		common.NamespaceTreeBenchmark,
+		common.SkewedUpdateBenchmark,
 	}
 
 	quit := make(chan os.Signal, 1)
@@ -72,9 +101,24 @@ func runSuite(catalog string) error {
 							Entity:      entity,
 							Duration:    duration,
 						}
+						if benchmark == common.ParentChildBenchmark {
+							experiment.Parent = common.SchemaEntity
+						}
+						if benchmark == common.PaginationBenchmark {
+							experiment.Populate = 1000
+							experiment.PageSize = 10
+						}
+						if benchmark == common.NamespaceTreeBenchmark {
+							experiment.Depth = 3
+							experiment.FanOut = 3
+						}
+						if benchmark == common.SkewedUpdateBenchmark {
+							experiment.Keys = 100
+							experiment.Distribution = &common.Distribution{Type: common.ZipfianDistribution, Theta: 0.99}
+						}
 
 						log.Printf("Running benchmark: %d, Entity: %s, Threads: %d, Duration: %d seconds\n", benchmark, entity, thread, duration)
-						if err := runBenchmark(experiment); err != nil {
+						if err := runBenchmark(experiment, options); err != nil {
 							log.Printf("Error benchmark: %s\n", err)
 						}
 					}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// setupTracing exports the spans of the operations and their requests to an
+// OTLP/HTTP endpoint, given as host:port or URL, and to a file of one JSON span
+// per line. Without either, spans are dropped. The returned function flushes
+// the remaining spans and has to be called before the process exits.
+func setupTracing(endpoint string, filename string) (func(), error) {
+	if endpoint == "" && filename == "" {
+		return func() {}, nil
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "benchmark-driver"))),
+	}
+	var file *os.File
+	if endpoint != "" {
+		endpointOption := otlptracehttp.WithEndpoint(endpoint)
+		if strings.Contains(endpoint, "://") {
+			endpointOption = otlptracehttp.WithEndpointURL(endpoint)
+		}
+		exporter, err := otlptracehttp.New(context.Background(), endpointOption, otlptracehttp.WithInsecure())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	if filename != "" {
+		var err error
+		file, err = os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to create the file exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Printf("Error exporting spans: %s", err)
+		}
+		if file != nil {
+			file.Close()
+		}
+	}, nil
+}
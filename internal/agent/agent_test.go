@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/catalog/unity"
+	"benchmark/internal/common"
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunTwoAgents runs a benchmark on two agents on localhost against a stub
+// catalog and checks that the coordinator collects the logs of every thread.
+func TestRunTwoAgents(t *testing.T) {
+	catalogServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer catalogServer.Close()
+	unityHost := unity.Host
+	unity.Host = strings.TrimPrefix(catalogServer.URL, "http://")
+	defer func() { unity.Host = unityHost }()
+
+	setupCatalog := func(catalog string) (internal.Catalog, error) {
+		return &unity.Catalog{}, nil
+	}
+	agents := make([]string, 2)
+	for i := range agents {
+		server := httptest.NewServer(NewServer(t.TempDir(), setupCatalog).Handler())
+		defer server.Close()
+		agents[i] = strings.TrimPrefix(server.URL, "http://")
+	}
+
+	experiment := common.Experiment{
+		ID:          uuid.New(),
+		Catalog:     "unity",
+		BenchmarkID: common.CreateBenchmark,
+		Threads:     3,
+		Entity:      common.CatalogEntity,
+		Anchor:      time.Now(),
+		Duration:    200 * time.Millisecond,
+	}
+	workers := []internal.WorkerConfig{
+		{WorkerFunc: internal.CreateCatalogWorker, Threads: 3, Params: map[string]interface{}{}},
+	}
+
+	logDir := t.TempDir()
+	threads, err := Run(context.Background(), experiment, workers, nil, agents, logDir)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if threads != 3 {
+		t.Errorf("Run() = %d threads, want 3", threads)
+	}
+
+	threadAgents := make(map[int]string)
+	files, _ := filepath.Glob(filepath.Join(logDir, "*.jsonl"))
+	if len(files) != len(agents) {
+		t.Fatalf("collected %d log files, want %d", len(files), len(agents))
+	}
+	for _, file := range files {
+		logFile, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(logFile)
+		for scanner.Scan() {
+			var entry common.LogEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid log entry %s: %v", scanner.Text(), err)
+			}
+			if entry.ExperimentID != experiment.ID.String() {
+				t.Errorf("log entry of experiment %s, want %s", entry.ExperimentID, experiment.ID)
+			}
+			if agent, seen := threadAgents[entry.ThreadID]; seen && agent != entry.Agent {
+				t.Errorf("thread %d logged from agents %s and %s", entry.ThreadID, agent, entry.Agent)
+			}
+			threadAgents[entry.ThreadID] = entry.Agent
+		}
+		logFile.Close()
+	}
+
+	agentThreads := make(map[string]int)
+	for thread := range 3 {
+		agent, seen := threadAgents[thread]
+		if !seen {
+			t.Errorf("no log entries of thread %d", thread)
+		}
+		agentThreads[agent]++
+	}
+	for _, agent := range agents {
+		if agentThreads[agent] == 0 {
+			t.Errorf("agent %s ran no threads", agent)
+		}
+	}
+}
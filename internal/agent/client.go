@@ -0,0 +1,227 @@
+package agent
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/common"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// clockSamples is the number of round trips of a clock measurement, of which
+// the shortest is used.
+const clockSamples = 8
+
+// startDelay is the time between sending the start time and starting, which
+// lets every agent receive the start time before it has passed.
+const startDelay = time.Second
+
+// Client is the connection of the coordinator to an agent.
+type Client struct {
+	Addr   string
+	client *http.Client
+}
+
+func NewClient(addr string) *Client {
+	return &Client{Addr: addr, client: &http.Client{}}
+}
+
+func (c *Client) post(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s%s", c.Addr, path), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("responded with %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// MeasureClock measures the offset of the clock of the agent like NTP, which
+// assumes that the agent read its clock halfway through the round trip, give
+// or take half the round trip.
+func (c *Client) MeasureClock(ctx context.Context) (common.ClockOffset, error) {
+	measured := common.ClockOffset{Agent: c.Addr}
+	roundTrip := time.Duration(-1)
+	for range clockSamples {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/clock", c.Addr), nil)
+		if err != nil {
+			return common.ClockOffset{}, err
+		}
+
+		sent := time.Now()
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return common.ClockOffset{}, err
+		}
+		var clock ClockResponse
+		err = json.NewDecoder(resp.Body).Decode(&clock)
+		resp.Body.Close()
+		received := time.Now()
+		if err != nil {
+			return common.ClockOffset{}, err
+		}
+
+		if sample := received.Sub(sent); roundTrip < 0 || sample < roundTrip {
+			roundTrip = sample
+			measured.Offset = clock.Time.Sub(sent.Add(sample / 2))
+			measured.Uncertainty = sample / 2
+		}
+	}
+	return measured, nil
+}
+
+// MeasureClocks measures the clock offsets of the agents.
+func MeasureClocks(ctx context.Context, agents []string) ([]common.ClockOffset, error) {
+	clocks := make([]common.ClockOffset, len(agents))
+	clients := make([]*Client, len(agents))
+	for i, addr := range agents {
+		clients[i] = NewClient(addr)
+	}
+	err := eachAgent(clients, func(i int, client *Client) error {
+		var err error
+		clocks[i], err = client.MeasureClock(ctx)
+		return err
+	})
+	return clocks, err
+}
+
+// EncodeJob encodes a job for an agent. Jobs of benchmarks whose threads share
+// state in the process, such as trackers for their audits, cannot be encoded.
+func EncodeJob(job Job) ([]byte, error) {
+	var body bytes.Buffer
+	if err := gob.NewEncoder(&body).Encode(job); err != nil {
+		return nil, fmt.Errorf("the threads share state that cannot leave the process: %w", err)
+	}
+	return body.Bytes(), nil
+}
+
+// Prepare sends an encoded job to the agent.
+func (c *Client) Prepare(ctx context.Context, job []byte) error {
+	return c.post(ctx, "/prepare", job)
+}
+
+// Start starts the prepared job at the given time and returns when it has finished.
+func (c *Client) Start(ctx context.Context, startAt time.Time) error {
+	body, err := json.Marshal(StartRequest{StartAt: startAt})
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, "/start", body)
+}
+
+// FetchLogs writes the logs of the experiment on the agent to the file.
+func (c *Client) FetchLogs(ctx context.Context, experimentID string, filename string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/logs/%s", c.Addr, experimentID), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("responded with %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
+
+// eachAgent calls f for every agent concurrently and joins their errors.
+func eachAgent(clients []*Client, f func(i int, client *Client) error) error {
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f(i, client); err != nil {
+				errs[i] = fmt.Errorf("agent %s: %w", client.Addr, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Run distributes the worker groups across the agents, starts them at the same
+// time and collects their logs into logDir, where they are merged with the logs
+// of the coordinator. The agents correct their clocks by the clock offsets of
+// the experiment. It returns the thread ID that follows the agents' threads.
+func Run(ctx context.Context, experiment common.Experiment, workers []internal.WorkerConfig, principals []internal.Principal, agents []string, logDir string) (int, error) {
+	clients := make([]*Client, len(agents))
+	jobs := make([][]byte, len(agents))
+	threadOffset := 0
+	for i, groups := range Distribute(workers, len(agents)) {
+		job := Job{Experiment: experiment, Agent: agents[i], ThreadOffset: threadOffset, Groups: groups, Principals: principals}
+		if i < len(experiment.Clocks) {
+			job.ClockOffset = experiment.Clocks[i].Offset
+		}
+		encoded, err := EncodeJob(job)
+		if err != nil {
+			return 0, fmt.Errorf("benchmark %d cannot run on agents: %w", experiment.BenchmarkID, err)
+		}
+		clients[i] = NewClient(agents[i])
+		jobs[i] = encoded
+		threadOffset += job.Threads()
+	}
+
+	err := eachAgent(clients, func(i int, client *Client) error {
+		return client.Prepare(ctx, jobs[i])
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare agents: %w", err)
+	}
+
+	startAt := time.Now().Add(startDelay)
+	log.Printf("Starting %d threads on %d agents at %s", threadOffset, len(agents), startAt.Format(time.RFC3339Nano))
+	runErr := eachAgent(clients, func(_ int, client *Client) error {
+		return client.Start(ctx, startAt)
+	})
+
+	// Logs are collected even after a failed run, as they show what went wrong
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create the log directory: %w", err)
+	}
+	fetchCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	fetchErr := eachAgent(clients, func(i int, client *Client) error {
+		return client.FetchLogs(fetchCtx, experiment.ID.String(), filepath.Join(logDir, fmt.Sprintf("%s_agent_%d.jsonl", experiment.ID, i)))
+	})
+	if fetchErr != nil {
+		log.Printf("Error fetching logs: %s", fetchErr)
+	}
+
+	if runErr != nil {
+		return 0, fmt.Errorf("failed to run agents: %w", runErr)
+	}
+	return threadOffset, nil
+}
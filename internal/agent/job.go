@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/common"
+	"encoding/gob"
+	"fmt"
+	"time"
+)
+
+func init() {
+	// Concrete types of the worker parameters, besides the basic types that gob knows
+	gob.Register(map[string]string{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(common.Payload{})
+	gob.Register(common.ThinkTime{})
+	gob.Register(common.Distribution{})
+	gob.Register(&internal.Manifest{})
+	gob.Register(&internal.KeyVersions{})
+}
+
+// Job is the share of a benchmark that runs on one agent.
+type Job struct {
+	Experiment   common.Experiment
+	Agent        string        // Address of the agent as known to the coordinator
+	ClockOffset  time.Duration // Clock of the agent minus clock of the coordinator
+	ThreadOffset int           // Thread ID of the first thread of the agent
+	Groups       []Group
+	Principals   []internal.Principal
+}
+
+// Group is a worker group, whose worker function is sent by name.
+type Group struct {
+	Worker  string
+	Threads int
+	Params  map[string]interface{}
+}
+
+// Threads returns the number of threads of the job.
+func (j Job) Threads() int {
+	threads := 0
+	for _, group := range j.Groups {
+		threads += group.Threads
+	}
+	return threads
+}
+
+// Configs returns the worker configs of the groups.
+func (j Job) Configs() ([]internal.WorkerConfig, error) {
+	workers := make([]internal.WorkerConfig, 0, len(j.Groups))
+	for _, group := range j.Groups {
+		workerFunc, exists := internal.LookupWorker(group.Worker)
+		if !exists {
+			return nil, fmt.Errorf("unknown worker %s", group.Worker)
+		}
+		workers = append(workers, internal.WorkerConfig{WorkerFunc: workerFunc, Threads: group.Threads, Params: group.Params})
+	}
+	return workers, nil
+}
+
+// Distribute splits the threads of every worker group evenly across the agents.
+// Groups with fewer threads than agents start on a different agent each, so that
+// single-threaded groups are spread as well. Audits are left out, as they run on
+// the coordinator once the agents have finished.
+func Distribute(workers []internal.WorkerConfig, agents int) [][]Group {
+	groups := make([][]Group, agents)
+	for i, worker := range workers {
+		if worker.Audit {
+			continue
+		}
+		for a := range agents {
+			threads := worker.Threads / agents
+			if (a-i%agents+agents)%agents < worker.Threads%agents {
+				threads++
+			}
+			if threads == 0 {
+				continue
+			}
+			groups[a] = append(groups[a], Group{Worker: internal.WorkerName(worker.WorkerFunc), Threads: threads, Params: worker.Params})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/common"
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJobRoundTrip(t *testing.T) {
+	versions := internal.NewKeyVersions([]string{"key", "other"})
+	versions.Acknowledged("key", 3)
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "basic types", params: map[string]interface{}{"catalogName": "c", "pageSize": 10, "rate": 2.5, "populated": []string{"a", "b"}}},
+		{name: "properties", params: map[string]interface{}{"properties": map[string]string{"k": "v"}}},
+		{name: "payload", params: map[string]interface{}{"payload": common.Payload{Columns: common.Range{Min: 1, Max: 5}}}},
+		{name: "think time", params: map[string]interface{}{"thinkTime": common.ThinkTime{Type: common.FixedThinkTime, Mean: time.Second}}},
+		{name: "distribution", params: map[string]interface{}{"distribution": common.Distribution{Type: common.ZipfianDistribution, Theta: 0.99}}},
+		{name: "manifest", params: map[string]interface{}{"manifest": &internal.Manifest{Catalogs: []internal.ManifestEntry{{Catalog: "c"}}}}},
+		{name: "key versions", params: map[string]interface{}{"versions": versions}},
+		{name: "shared tracker", params: map[string]interface{}{"tracker": internal.NewParentTracker()}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := Job{
+				Experiment:   common.Experiment{Catalog: "polaris", Threads: 4},
+				Agent:        "127.0.0.1:7071",
+				ClockOffset:  time.Millisecond,
+				ThreadOffset: 2,
+				Groups:       []Group{{Worker: internal.WorkerName(internal.CreateCatalogWorker), Threads: 2, Params: test.params}},
+				Principals:   []internal.Principal{{Name: "p", Token: "t"}},
+			}
+
+			body, err := EncodeJob(job)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("EncodeJob error = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var decoded Job
+			if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&decoded); err != nil {
+				t.Fatalf("failed to decode the job: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, job) {
+				t.Errorf("decoded job = %+v, want %+v", decoded, job)
+			}
+			if _, err := decoded.Configs(); err != nil {
+				t.Errorf("Configs() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package agent
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/common"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StartRequest starts the prepared job of an agent at the same time on all agents.
+type StartRequest struct {
+	StartAt time.Time `json:"start_at"` // On the clock of the coordinator
+}
+
+// ClockResponse is the time of the agent, with which the coordinator measures the clock offset.
+type ClockResponse struct {
+	Time time.Time `json:"time"`
+}
+
+// Server runs the jobs that a coordinator sends, one at a time.
+type Server struct {
+	dir          string // Directory of the per-thread logs of the jobs
+	setupCatalog func(catalog string) (internal.Catalog, error)
+	mu           sync.Mutex
+	engine       *internal.BenchmarkEngine // Engine of the prepared job
+	workers      []internal.WorkerConfig
+	clockOffset  time.Duration
+	Metrics      *internal.Metrics // Counts the requests of the jobs for Prometheus, if set
+}
+
+func NewServer(dir string, setupCatalog func(catalog string) (internal.Catalog, error)) *Server {
+	return &Server{dir: dir, setupCatalog: setupCatalog}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /clock", s.clock)
+	mux.HandleFunc("POST /prepare", s.prepare)
+	mux.HandleFunc("POST /start", s.start)
+	mux.HandleFunc("GET /logs/{experiment}", s.logs)
+	return mux
+}
+
+func (s *Server) clock(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ClockResponse{Time: time.Now().UTC()})
+}
+
+// localTime returns the monotonic time on this agent that corresponds to a
+// wall-clock time of the coordinator.
+func localTime(coordinatorTime time.Time, clockOffset time.Duration) time.Time {
+	now := time.Now()
+	return now.Add(coordinatorTime.Add(clockOffset).Sub(now))
+}
+
+// prepare sets up the catalog and the workers of a job, so that the job can
+// start without delay.
+func (s *Server) prepare(w http.ResponseWriter, r *http.Request) {
+	var job Job
+	if err := gob.NewDecoder(r.Body).Decode(&job); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode job: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	workers, err := job.Configs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	catalog, err := s.setupCatalog(job.Experiment.Catalog)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to setup catalog: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	experimentID := job.Experiment.ID.String()
+	engine := internal.NewBenchmarkEngine(experimentID, catalog, job.Threads(), job.Experiment.Duration)
+	engine.Seed = job.Experiment.Seed
+	engine.Entity = job.Experiment.Entity
+	engine.Summary = job.Experiment.Summary
+	engine.BodySample = job.Experiment.BodySample
+	engine.ThreadOffset = job.ThreadOffset
+	engine.Principals = job.Principals
+	engine.LogDir = filepath.Join(s.dir, experimentID)
+	engine.Agent = job.Agent
+	// Elapsed times are measured from the anchor of the coordinator, corrected by the clock offset
+	engine.Anchor = localTime(job.Experiment.Anchor, job.ClockOffset)
+	if s.Metrics != nil {
+		engine.Metrics = s.Metrics.For(job.Experiment.Catalog, string(job.Experiment.Entity))
+	}
+
+	// A job that never started, e.g. because another agent failed to prepare, is replaced
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.engine != nil {
+		log.Printf("Replacing prepared experiment %s", s.engine.ExperimentID)
+	}
+	s.engine = engine
+	s.workers = workers
+	s.clockOffset = job.ClockOffset
+
+	log.Printf("Prepared experiment %s with %d threads from thread %d", experimentID, job.Threads(), job.ThreadOffset)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// start runs the prepared job from the requested start time and responds when
+// the job has finished. The job stops early when the coordinator goes away.
+func (s *Server) start(w http.ResponseWriter, r *http.Request) {
+	var request StartRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode start request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	engine, workers, clockOffset := s.engine, s.workers, s.clockOffset
+	s.engine, s.workers = nil, nil
+	s.mu.Unlock()
+	if engine == nil {
+		http.Error(w, "no job is prepared", http.StatusConflict)
+		return
+	}
+
+	startAt := localTime(request.StartAt, clockOffset)
+	log.Printf("Starting experiment %s in %s", engine.ExperimentID, time.Until(startAt).Round(time.Millisecond))
+	common.Sleep(r.Context(), time.Until(startAt))
+	engine.RunWorkers(r.Context(), workers)
+	if err := r.Context().Err(); err != nil {
+		log.Printf("Stopped experiment %s: %v", engine.ExperimentID, err)
+		return
+	}
+
+	log.Printf("Finished experiment %s", engine.ExperimentID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// logs streams the per-thread logs of an experiment as one JSONL file and
+// removes them afterwards.
+func (s *Server) logs(w http.ResponseWriter, r *http.Request) {
+	logDir := filepath.Join(s.dir, filepath.Base(r.PathValue("experiment")))
+	files, err := filepath.Glob(filepath.Join(logDir, "*.jsonl"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/jsonl")
+	for _, file := range files {
+		logFile, err := os.Open(file)
+		if err != nil {
+			log.Printf("Error reading logs: %s", err)
+			return
+		}
+		_, err = io.Copy(w, logFile)
+		logFile.Close()
+		if err != nil {
+			log.Printf("Error sending logs: %s", err)
+			return
+		}
+	}
+
+	if err := os.RemoveAll(logDir); err != nil {
+		log.Printf("Error deleting logs: %s", err)
+	}
+}
@@ -7,59 +7,76 @@ import (
 
 type Catalog interface {
 	// Catalog
-	CreateCatalog(ctx context.Context, name string) (*http.Response, error)
+	CreateCatalog(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error)
 	GetCatalog(ctx context.Context, name string) (*http.Response, error)
 	UpdateCatalog(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error)
 	DeleteCatalog(ctx context.Context, name string) (*http.Response, error)
 	ListCatalogs(ctx context.Context, params map[string]interface{}) ([]*http.Response, error)
 
 	// Principal
-	CreatePrincipal(ctx context.Context, name string) (*http.Response, error)
+	CreatePrincipal(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error)
 	GetPrincipal(ctx context.Context, name string) (*http.Response, error)
 	UpdatePrincipal(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error)
 	DeletePrincipal(ctx context.Context, name string) (*http.Response, error)
 	ListPrincipals(ctx context.Context, params map[string]interface{}) ([]*http.Response, error)
 
-	// Schema
-	CreateSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error)
+	// Schema, whose name is a namespace path with levels separated by common.NamespaceSeparator
+	CreateSchema(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) (*http.Response, error)
 	GetSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error)
 	UpdateSchema(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) (*http.Response, error)
 	DeleteSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error)
 	ListSchemas(ctx context.Context, catalogName string, params map[string]interface{}) ([]*http.Response, error)
 
 	// Table
-	CreateTable(ctx context.Context, catalogName string, schemaName string, tableName string) (*http.Response, error)
+	CreateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error)
 	GetTable(ctx context.Context, catalogName string, schemaName string, tableName string) (*http.Response, error)
 	UpdateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error)
 	DeleteTable(ctx context.Context, catalogName string, schemaName string, tableName string) (*http.Response, error)
 	ListTables(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error)
 
 	// View
-	CreateView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error)
+	CreateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error)
 	GetView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error)
 	UpdateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error)
 	DeleteView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error)
 	ListViews(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error)
 
 	// Function
-	CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error)
+	CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string, params map[string]interface{}) (*http.Response, error)
 	GetFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error)
 	DeleteFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error)
 	ListFunctions(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error)
 
 	// Model
-	CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error)
+	CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error)
 	GetModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error)
 	UpdateModel(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error)
 	DeleteModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error)
 	ListModels(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error)
 
+	// Model version
+	CreateModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error)
+	GetModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error)
+	FinalizeModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error)
+	ListModelVersions(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) ([]*http.Response, error)
+
 	// Volume
-	CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error)
+	CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string, params map[string]interface{}) (*http.Response, error)
 	GetVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error)
 	UpdateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string, params map[string]interface{}) (*http.Response, error)
 	DeleteVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error)
 	ListVolumes(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error)
 
+	// Permission
 	GrantPermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error)
+	RevokePermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error)
+	CreateCatalogRole(ctx context.Context, catalogName string, roleName string) (*http.Response, error)
+	CreatePrincipalRole(ctx context.Context, roleName string) (*http.Response, error)
+	GrantCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error)
+	RevokeCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error)
+	GrantPrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error)
+	RevokePrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error)
+
+	// Transaction
+	CommitTransaction(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error)
 }
@@ -1,5 +1,7 @@
 package polaris
 
+import "encoding/json"
+
 // Polaris Schemas
 
 type CatalogStorageConfigInfo struct {
@@ -9,6 +11,25 @@ type CatalogStorageConfigInfo struct {
 type CatalogProperties struct {
 	DefaultBaseLocation string            `json:"default-base-location"`
 	AdditionalProps     map[string]string `json:"-"`
+	ExtraProps          map[string]string `json:"-"` // Sent next to default-base-location, unlike AdditionalProps
+}
+
+// MarshalJSON flattens the extra properties next to default-base-location, as
+// Polaris expects a single property map. Without extra properties the body is
+// the one of the plain struct.
+func (p CatalogProperties) MarshalJSON() ([]byte, error) {
+	if p.ExtraProps == nil {
+		type plain CatalogProperties
+		return json.Marshal(plain(p))
+	}
+	properties := make(map[string]string, len(p.ExtraProps)+1)
+	for k, v := range p.ExtraProps {
+		properties[k] = v
+	}
+	if p.DefaultBaseLocation != "" {
+		properties["default-base-location"] = p.DefaultBaseLocation
+	}
+	return json.Marshal(properties)
 }
 
 // Ends with Model to avoid collision with the Catalog interface
@@ -69,6 +90,21 @@ type UpdateTableBody struct {
 	Updates      []map[string]interface{} `json:"updates,omitempty"`
 }
 
+type TableIdentifier struct {
+	Namespace []string `json:"namespace"`
+	Name      string   `json:"name"`
+}
+
+type TableChange struct {
+	Identifier   TableIdentifier          `json:"identifier"`
+	Requirements []map[string]interface{} `json:"requirements"`
+	Updates      []map[string]interface{} `json:"updates"`
+}
+
+type CommitTransactionBody struct {
+	TableChanges []TableChange `json:"table-changes"`
+}
+
 type CreateTableBody struct {
 	Name        string            `json:"name"`
 	Schema      TableSchema       `json:"schema"`
@@ -96,6 +132,15 @@ type CreatePrincipalBody struct {
 	Principal                  Principal `json:"principal"`
 	CredentialRotationRequired bool      `json:"credentialRotationRequired"`
 }
+type Role struct {
+	Name string `json:"name"`
+}
+type CreateCatalogRoleBody struct {
+	CatalogRole Role `json:"catalogRole"`
+}
+type CreatePrincipalRoleBody struct {
+	PrincipalRole Role `json:"principalRole"`
+}
 type GrantCatalogPermissionBody struct {
 	Grants GrantPrivilege `json:"grant"`
 }
@@ -115,6 +160,7 @@ type CreateViewBody struct {
 	Location    string              `json:"location"`
 	Schema      ViewBodySchema      `json:"schema"`
 	ViewVersion ViewBodyViewVersion `json:"view-version"`
+	Properties  map[string]string   `json:"properties,omitempty"`
 }
 type ViewBodySchema struct {
 	Type   string        `json:"type"`
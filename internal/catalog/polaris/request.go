@@ -4,12 +4,11 @@ import (
 	"benchmark/internal/common"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,27 +26,78 @@ func SetToken(token string) {
 
 var client = &http.Client{
 	Timeout: time.Second * 30,
-	Transport: &http.Transport{
+	Transport: &common.ObservedTransport{Base: &http.Transport{
 		MaxIdleConns:        10000,
 		MaxIdleConnsPerHost: 1000,
 		MaxConnsPerHost:     1000,
 		DisableKeepAlives:   false,
 		IdleConnTimeout:     90 * time.Second,
 		TLSHandshakeTimeout: 10 * time.Second,
-	},
+	}},
 }
 
 type Catalog struct{}
 
-func (c *Catalog) CreateCatalog(ctx context.Context, name string) (*http.Response, error) {
+// namespaceLevels splits a schema name into the levels of its namespace path.
+func namespaceLevels(schemaName string) []string {
+	return strings.Split(schemaName, common.NamespaceSeparator)
+}
+
+// schemaFields converts generated columns into Iceberg schema fields, numbering
+// the field IDs depth-first after nextID.
+func schemaFields(columns []common.Column, nextID *int) []interface{} {
+	fields := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		*nextID++
+		field := map[string]interface{}{
+			"id":       *nextID,
+			"name":     column.Name,
+			"required": false,
+			"type":     column.Type,
+		}
+		if column.Type == "struct" {
+			field["type"] = map[string]interface{}{
+				"type":   "struct",
+				"fields": schemaFields(column.Fields, nextID),
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// withComment returns a copy of the properties with the generated comment, as
+// Iceberg stores comments as a property.
+func withComment(properties map[string]string, params map[string]interface{}) map[string]string {
+	comment, ok := params["comment"].(string)
+	if !ok {
+		return properties
+	}
+	result := make(map[string]string, len(properties)+1)
+	for k, v := range properties {
+		result[k] = v
+	}
+	result["comment"] = comment
+	return result
+}
+
+// namespacePath encodes a schema name for a URL path, where Iceberg REST
+// separates namespace levels with the unit separator.
+func namespacePath(schemaName string) string {
+	return strings.Join(namespaceLevels(schemaName), "%1F")
+}
+
+func (c *Catalog) CreateCatalog(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
+	properties, _ := params["properties"].(map[string]string)
 	body := CreateCatalogBody{
 		Catalog: CatalogModel{
 			EntityType: "INTERNAL",
 			Name:       name,
 			Properties: CatalogProperties{
 				DefaultBaseLocation: fmt.Sprintf("file:///tmp/%s/", name),
+				ExtraProps:          withComment(properties, params),
 			},
 			StorageConfigInfo: CatalogStorageConfigInfo{
 				StorageType: "FILE",
@@ -87,17 +137,54 @@ func (c *Catalog) DeleteCatalog(ctx context.Context, name string) (*http.Respons
 }
 
 func (c *Catalog) ListCatalogs(ctx context.Context, params map[string]interface{}) ([]*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetEndpoint("/catalogs").Build(ctx, Host, PathManagement, Token)
-	if err != nil {
-		return nil, err
+	pageToken, ok := params["pageToken"].(string)
+	if !ok {
+		pageToken = ""
+	}
+
+	maxResults, ok := params["maxResults"].(int)
+	if !ok {
+		maxResults = 0
 	}
 
 	responses := make([]*http.Response, 0)
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	for {
+		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint("/catalogs")
+
+		if pageToken != "" {
+			builder.AddQueryParam("pageToken", pageToken)
+		}
+		if maxResults != 0 {
+			builder.AddQueryParam("pageSize", strconv.Itoa(maxResults))
+		}
+
+		req, err := builder.Build(ctx, Host, PathManagement, Token)
+		if err != nil {
+			return responses, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return responses, err
+		}
+
+		responses = append(responses, resp)
+
+		var body struct {
+			NextPageToken string `json:"next-page-token"`
+		}
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
+		}
+		if body.NextPageToken == "" {
+			break
+		}
+		pageToken = body.NextPageToken
 	}
-	responses = append(responses, resp)
+
 	return responses, nil
 }
 
@@ -110,7 +197,7 @@ func (c *Catalog) GetPrincipal(ctx context.Context, name string) (*http.Response
 }
 
 func (c *Catalog) GetSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s", catalogName, schemaName)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s", catalogName, namespacePath(schemaName))).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +205,7 @@ func (c *Catalog) GetSchema(ctx context.Context, catalogName string, schemaName
 }
 
 func (c *Catalog) GetTable(ctx context.Context, catalogName string, schemaName string, name string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, schemaName, name)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, namespacePath(schemaName), name)).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +221,14 @@ func (c *Catalog) UpdateCatalog(ctx context.Context, name string, params map[str
 		},
 	}
 
+	// Replaces the whole property map when given, e.g. for read-modify-write updates
+	if properties, ok := params["properties"].(map[string]string); ok {
+		catalogProperties = CatalogProperties{
+			DefaultBaseLocation: properties["default-base-location"],
+			ExtraProps:          properties,
+		}
+	}
+
 	body := UpdateCatalogBody{
 		CurrentEntityVersion: entityVersion,
 		Properties:           catalogProperties,
@@ -177,19 +272,22 @@ func (c *Catalog) UpdatePrincipal(ctx context.Context, name string, params map[s
 }
 
 func (c *Catalog) UpdateSchema(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) (*http.Response, error) {
-	entityVersion := params["entityVersion"].(int)
+	properties, ok := params["properties"].(map[string]string)
+	if !ok {
+		properties = map[string]string{
+			"entityVersion": strconv.Itoa(params["entityVersion"].(int)),
+		}
+	}
 
 	body := UpdateNamespaceBody{
-		Updates: map[string]string{
-			"entityVersion": strconv.Itoa(entityVersion),
-		},
+		Updates: properties,
 	}
 
 	jsonBody, err := common.MarshalJSON(body)
 	if err != nil {
 		return nil, err
 	}
-	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/properties", catalogName, schemaName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/properties", catalogName, namespacePath(schemaName))).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -197,16 +295,26 @@ func (c *Catalog) UpdateSchema(ctx context.Context, catalogName string, schemaNa
 }
 
 func (c *Catalog) UpdateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error) {
-	entityVersion := params["entityVersion"].(int)
-	body := UpdateTableBody{
-		Updates: []map[string]interface{}{
+	body := UpdateTableBody{}
+
+	// Raw table updates and requirements are committed as given, e.g. for snapshot commits
+	if updates, ok := params["updates"].([]map[string]interface{}); ok {
+		body.Updates = updates
+		body.Requirements, _ = params["requirements"].([]map[string]interface{})
+	} else {
+		properties, ok := params["properties"].(map[string]string)
+		if !ok {
+			properties = map[string]string{
+				"entityVersion": strconv.Itoa(params["entityVersion"].(int)),
+			}
+		}
+
+		body.Updates = []map[string]interface{}{
 			{
-				"action": "set-properties",
-				"updates": map[string]string{
-					"entityVersion": strconv.Itoa(entityVersion),
-				},
+				"action":  "set-properties",
+				"updates": properties,
 			},
-		},
+		}
 	}
 
 	jsonBody, err := common.MarshalJSON(body)
@@ -214,7 +322,7 @@ func (c *Catalog) UpdateTable(ctx context.Context, catalogName string, schemaNam
 		return nil, err
 	}
 
-	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, schemaName, tableName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, namespacePath(schemaName), tableName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -236,10 +344,12 @@ func (c *Catalog) ListPrincipals(ctx context.Context, params map[string]interfac
 	return responses, nil
 }
 
-func (c *Catalog) CreatePrincipal(ctx context.Context, name string) (*http.Response, error) {
+func (c *Catalog) CreatePrincipal(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error) {
+	properties, _ := params["properties"].(map[string]string)
 	body := CreatePrincipalBody{
 		Principal: Principal{
-			Name: name,
+			Name:       name,
+			Properties: properties,
 		},
 		CredentialRotationRequired: false,
 	}
@@ -266,7 +376,7 @@ func (c *Catalog) DeletePrincipal(ctx context.Context, name string) (*http.Respo
 }
 
 func (c *Catalog) DeleteSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s", catalogName, schemaName)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s", catalogName, namespacePath(schemaName))).Build(ctx, Host, PathCatalog, Token)
 
 	if err != nil {
 		return nil, err
@@ -289,6 +399,10 @@ func (c *Catalog) ListSchemas(ctx context.Context, catalogName string, params ma
 	for {
 		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces", catalogName))
 
+		// Lists the children of a nested namespace instead of the top level namespaces
+		if parent, ok := params["parentNamespace"].(string); ok {
+			builder.AddQueryParam("parent", strings.Join(namespaceLevels(parent), "\x1F"))
+		}
 		if pageToken != "" {
 			builder.AddQueryParam("pageToken", pageToken)
 		}
@@ -298,22 +412,25 @@ func (c *Catalog) ListSchemas(ctx context.Context, catalogName string, params ma
 
 		req, err := builder.Build(ctx, Host, PathCatalog, Token)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
 
 		var body struct {
-			NextPageToken string `json:"nextPageToken"`
+			NextPageToken string `json:"next-page-token"`
+		}
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 		if body.NextPageToken == "" {
 			break
 		}
@@ -323,10 +440,14 @@ func (c *Catalog) ListSchemas(ctx context.Context, catalogName string, params ma
 	return responses, nil
 }
 
-func (c *Catalog) CreateSchema(ctx context.Context, catalogName string, schemaName string) (*http.Response, error) {
+func (c *Catalog) CreateSchema(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) (*http.Response, error) {
+	properties, ok := params["properties"].(map[string]string)
+	if !ok {
+		properties = map[string]string{}
+	}
 	body := CreateNamespaceBody{
-		Namespace:  []string{schemaName},
-		Properties: map[string]string{},
+		Namespace:  namespaceLevels(schemaName),
+		Properties: withComment(properties, params),
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -341,14 +462,17 @@ func (c *Catalog) CreateSchema(ctx context.Context, catalogName string, schemaNa
 	return client.Do(req)
 }
 
-func (c *Catalog) CreateTable(ctx context.Context, catalogName string, schemaName string, tableName string) (*http.Response, error) {
+func (c *Catalog) CreateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error) {
+	properties, _ := params["properties"].(map[string]string)
+	columns, _ := params["columns"].([]common.Column)
 	body := CreateTableBody{
 		Name: tableName,
 		Schema: TableSchema{
 			Type:   "struct",
-			Fields: make([]interface{}, 0),
+			Fields: schemaFields(columns, new(int)),
 		},
 		StageCreate: false,
+		Properties:  withComment(properties, params),
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -356,7 +480,7 @@ func (c *Catalog) CreateTable(ctx context.Context, catalogName string, schemaNam
 		log.Fatalf("Failed to marshal JSON: %v", err)
 	}
 
-	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables", catalogName, schemaName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables", catalogName, namespacePath(schemaName))).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 
 	if err != nil {
 		return nil, err
@@ -365,7 +489,7 @@ func (c *Catalog) CreateTable(ctx context.Context, catalogName string, schemaNam
 }
 
 func (c *Catalog) DeleteTable(ctx context.Context, catalogName string, schemaName string, tableName string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, schemaName, tableName)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables/%s", catalogName, namespacePath(schemaName), tableName)).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -373,7 +497,21 @@ func (c *Catalog) DeleteTable(ctx context.Context, catalogName string, schemaNam
 }
 
 func (c *Catalog) GrantPermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	return c.changePermissionCatalog(ctx, "PUT", catalogName, params)
+}
+
+func (c *Catalog) RevokePermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	return c.changePermissionCatalog(ctx, "POST", catalogName, params)
+}
+
+// changePermissionCatalog grants (PUT) or revokes (POST) a catalog privilege of
+// the catalog role in params["catalogRole"], which defaults to catalog_admin.
+func (c *Catalog) changePermissionCatalog(ctx context.Context, method string, catalogName string, params map[string]interface{}) (*http.Response, error) {
 	privilege := params["privilege"].(string)
+	catalogRole, ok := params["catalogRole"].(string)
+	if !ok {
+		catalogRole = "catalog_admin"
+	}
 	body := GrantCatalogPermissionBody{
 		Grants: GrantPrivilege{
 			Privilege: privilege,
@@ -386,7 +524,107 @@ func (c *Catalog) GrantPermissionCatalog(ctx context.Context, catalogName string
 		return nil, err
 	}
 
-	req, err := common.NewRequestBuilder().SetMethod("PUT").SetEndpoint(fmt.Sprintf("catalogs/%s/catalog-roles/catalog_admin/grants", catalogName)).SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	req, err := common.NewRequestBuilder().SetMethod(method).SetEndpoint(fmt.Sprintf("catalogs/%s/catalog-roles/%s/grants", catalogName, catalogRole)).SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) CreateCatalogRole(ctx context.Context, catalogName string, roleName string) (*http.Response, error) {
+	jsonBody, err := common.MarshalJSON(CreateCatalogRoleBody{CatalogRole: Role{Name: roleName}})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("catalogs/%s/catalog-roles", catalogName)).SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) CreatePrincipalRole(ctx context.Context, roleName string) (*http.Response, error) {
+	jsonBody, err := common.MarshalJSON(CreatePrincipalRoleBody{PrincipalRole: Role{Name: roleName}})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint("/principal-roles").SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) GrantCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error) {
+	jsonBody, err := common.MarshalJSON(CreateCatalogRoleBody{CatalogRole: Role{Name: catalogRoleName}})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := common.NewRequestBuilder().SetMethod("PUT").SetEndpoint(fmt.Sprintf("/principal-roles/%s/catalog-roles/%s", principalRoleName, catalogName)).SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) RevokeCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error) {
+	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("/principal-roles/%s/catalog-roles/%s/%s", principalRoleName, catalogName, catalogRoleName)).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) GrantPrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error) {
+	jsonBody, err := common.MarshalJSON(CreatePrincipalRoleBody{PrincipalRole: Role{Name: principalRoleName}})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := common.NewRequestBuilder().SetMethod("PUT").SetEndpoint(fmt.Sprintf("/principals/%s/principal-roles", principalName)).SetJSONBody(jsonBody).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) RevokePrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error) {
+	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("/principals/%s/principal-roles/%s", principalName, principalRoleName)).Build(ctx, Host, PathManagement, Token)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) CommitTransaction(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	schemaName := params["schemaName"].(string)
+	tableNames := params["tableNames"].([]string)
+	updates := params["updates"].([]map[string]interface{})
+
+	// Applies the same updates to every table in a single atomic commit
+	body := CommitTransactionBody{
+		TableChanges: make([]TableChange, 0, len(tableNames)),
+	}
+	for _, tableName := range tableNames {
+		body.TableChanges = append(body.TableChanges, TableChange{
+			Identifier: TableIdentifier{
+				Namespace: namespaceLevels(schemaName),
+				Name:      tableName,
+			},
+			Requirements: []map[string]interface{}{},
+			Updates:      updates,
+		})
+	}
+
+	jsonBody, err := common.MarshalJSON(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/transactions/commit", catalogName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -406,7 +644,7 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 
 	responses := make([]*http.Response, 0)
 	for {
-		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables", catalogName, schemaName))
+		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/tables", catalogName, namespacePath(schemaName)))
 
 		if pageToken != "" {
 			builder.AddQueryParam("pageToken", pageToken)
@@ -417,24 +655,25 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 
 		req, err := builder.Build(ctx, Host, PathCatalog, Token)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
 		var result struct {
-			NextPageToken string `json:"nextPageToken"`
+			NextPageToken string `json:"next-page-token"`
 		}
 
-		body, _ := io.ReadAll(resp.Body)
-		err = json.Unmarshal(body, &result)
+		body, err := common.ReadBody(resp)
 		if err != nil {
-			return nil, err
+			return responses, err
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 		if result.NextPageToken == "" {
 			break
 		}
@@ -445,29 +684,43 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 	return responses, nil
 }
 
-func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
+func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error) {
+	properties, _ := params["properties"].(map[string]string)
+	columns, _ := params["columns"].([]common.Column)
+	representations, ok := params["representations"].(int)
+	if !ok {
+		representations = 1
+	}
+
+	// A view version has at most one representation per dialect
 	body := CreateViewBody{
 		Name:     viewName,
 		Location: fmt.Sprintf("file:///tmp/%s/%s/", catalogName, schemaName),
 		Schema: ViewBodySchema{
 			Type:   "struct",
-			Fields: []interface{}{},
+			Fields: schemaFields(columns, new(int)),
 		},
 		ViewVersion: ViewBodyViewVersion{
-			VersionId:   0,
-			TimestampMs: 0,
-			SchemaId:    0,
-			Summary:     map[string]string{},
-			Representations: []ViewBodyViewVersionRepresentation{{
-				Type:    "sql",
-				Sql:     "SELECT 1 AS test_column",
-				Dialect: "ansi",
-			}},
-			DefaultCatalog: catalogName,
-			DefaultNamespace: []string{
-				schemaName,
-			},
+			VersionId:        0,
+			TimestampMs:      0,
+			SchemaId:         0,
+			Summary:          map[string]string{},
+			Representations:  make([]ViewBodyViewVersionRepresentation, 0, representations),
+			DefaultCatalog:   catalogName,
+			DefaultNamespace: namespaceLevels(schemaName),
 		},
+		Properties: withComment(properties, params),
+	}
+	for i := range representations {
+		dialect := "ansi"
+		if i > 0 {
+			dialect = fmt.Sprintf("dialect_%d", i)
+		}
+		body.ViewVersion.Representations = append(body.ViewVersion.Representations, ViewBodyViewVersionRepresentation{
+			Type:    "sql",
+			Sql:     "SELECT 1 AS test_column",
+			Dialect: dialect,
+		})
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -475,7 +728,7 @@ func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName
 		log.Fatalf("Failed to marshal JSON: %v", err)
 	}
 
-	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views", catalogName, schemaName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views", catalogName, namespacePath(schemaName))).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -483,7 +736,7 @@ func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName
 }
 
 func (c *Catalog) DeleteView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, schemaName, viewName)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("DELETE").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, namespacePath(schemaName), viewName)).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -491,7 +744,7 @@ func (c *Catalog) DeleteView(ctx context.Context, catalogName string, schemaName
 }
 
 func (c *Catalog) GetView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
-	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, schemaName, viewName)).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, namespacePath(schemaName), viewName)).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
@@ -512,7 +765,7 @@ func (c *Catalog) ListViews(ctx context.Context, catalogName string, schemaName
 	responses := make([]*http.Response, 0)
 	for {
 
-		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views", catalogName, schemaName))
+		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views", catalogName, namespacePath(schemaName)))
 
 		if pageToken != "" {
 			builder.AddQueryParam("pageToken", pageToken)
@@ -522,23 +775,25 @@ func (c *Catalog) ListViews(ctx context.Context, catalogName string, schemaName
 		}
 		req, err := builder.Build(ctx, Host, PathCatalog, Token)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 		responses = append(responses, resp)
 
 		var body struct {
-			NextPageToken string `json:"nextPageToken"`
+			NextPageToken string `json:"next-page-token"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -550,17 +805,23 @@ func (c *Catalog) ListViews(ctx context.Context, catalogName string, schemaName
 }
 
 func (c *Catalog) UpdateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error) {
-	properties, ok := params["properties"].(map[string]string)
-	if !ok {
-		properties = make(map[string]string)
-	}
-	body := UpdateViewBody{
-		Updates: []map[string]interface{}{
+	body := UpdateViewBody{}
+
+	// Raw view updates and requirements are committed as given, e.g. for new view versions
+	if updates, ok := params["updates"].([]map[string]interface{}); ok {
+		body.Updates = updates
+		body.Requirements, _ = params["requirements"].([]map[string]interface{})
+	} else {
+		properties, ok := params["properties"].(map[string]string)
+		if !ok {
+			properties = make(map[string]string)
+		}
+		body.Updates = []map[string]interface{}{
 			{
 				"action":  "set-properties",
 				"updates": properties,
 			},
-		},
+		}
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -568,52 +829,64 @@ func (c *Catalog) UpdateView(ctx context.Context, catalogName string, schemaName
 		log.Fatalf("Failed to marshal JSON: %v", err)
 	}
 
-	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, schemaName, viewName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint(fmt.Sprintf("%s/namespaces/%s/views/%s", catalogName, namespacePath(schemaName), viewName)).SetJSONBody(jsonBody).Build(ctx, Host, PathCatalog, Token)
 	if err != nil {
 		return nil, err
 	}
 	return client.Do(req)
 }
 
-func (c *Catalog) CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) GetFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) DeleteFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) ListFunctions(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
-func (c *Catalog) CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) GetModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) UpdateModel(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) DeleteModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) ListModels(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) CreateModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) GetModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) FinalizeModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) ListModelVersions(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) ([]*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
-func (c *Catalog) CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) GetVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) UpdateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) DeleteVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) ListVolumes(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
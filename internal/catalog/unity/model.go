@@ -66,6 +66,11 @@ type ListModelsResponse struct {
 	NextPageToken string        `json:"next_page_token"`
 }
 
+type ListModelVersionsResponse struct {
+	ModelVersions []interface{} `json:"model_versions"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
 type ListVolumesResponse struct {
 	Volumes       []interface{} `json:"volumes"`
 	NextPageToken string        `json:"next_page_token"`
@@ -91,12 +96,24 @@ type CreateNamespaceBody struct {
 }
 
 type CreateTableBody struct {
-	Name             string `json:"name"`
-	CatalogName      string `json:"catalog_name"`
-	SchemaName       string `json:"schema_name"`
-	TableType        string `json:"table_type"`
-	DataSourceFormat string `json:"data_source_format"`
-	StorageLocation  string `json:"storage_location"`
+	Name             string            `json:"name"`
+	CatalogName      string            `json:"catalog_name"`
+	SchemaName       string            `json:"schema_name"`
+	TableType        string            `json:"table_type"`
+	DataSourceFormat string            `json:"data_source_format"`
+	StorageLocation  string            `json:"storage_location"`
+	Columns          []ColumnInfo      `json:"columns,omitempty"`
+	Comment          string            `json:"comment,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type ColumnInfo struct {
+	Name     string `json:"name"`
+	TypeName string `json:"type_name"`
+	TypeText string `json:"type_text"`
+	TypeJson string `json:"type_json"`
+	Position int    `json:"position"`
+	Nullable bool   `json:"nullable"`
 }
 
 type UpdateSchemaBody struct {
@@ -151,6 +168,20 @@ type UpdateModelBody struct {
 	Comment string `json:"comment,omitempty"`
 }
 
+type CreateModelVersionBody struct {
+	CatalogName string `json:"catalog_name"`
+	SchemaName  string `json:"schema_name"`
+	ModelName   string `json:"model_name"`
+	Source      string `json:"source"`
+	RunId       string `json:"run_id,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+type FinalizeModelVersionBody struct {
+	FullName string `json:"full_name"`
+	Version  int64  `json:"version"`
+}
+
 type CreateVolumeBody struct {
 	Name            string `json:"name"`
 	CatalogName     string `json:"catalog_name"`
@@ -163,3 +194,13 @@ type UpdateVolumeBody struct {
 	NewName string `json:"new_name,omitempty"`
 	Comment string `json:"comment,omitempty"`
 }
+
+type PermissionsChange struct {
+	Principal string   `json:"principal"`
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+}
+
+type UpdatePermissionsBody struct {
+	Changes []PermissionsChange `json:"changes"`
+}
@@ -4,36 +4,42 @@ import (
 	"benchmark/internal/common"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var (
 	Host = common.GetEnv("UNITY_HOST", "localhost:8080")
 	Path = common.GetEnv("UNITY_PATH", "/api/2.1/unity-catalog")
+	// Principal is granted the catalog privileges when a grant names no principal
+	Principal = common.GetEnv("UNITY_PRINCIPAL", "admin")
 )
 
 var client = &http.Client{
 	Timeout: time.Second * 30,
-	Transport: &http.Transport{
+	Transport: &common.ObservedTransport{Base: &http.Transport{
 		MaxIdleConns:        10000,
 		MaxIdleConnsPerHost: 1000,
 		MaxConnsPerHost:     1000,
 		DisableKeepAlives:   false,
 		IdleConnTimeout:     90 * time.Second,
 		TLSHandshakeTimeout: 10 * time.Second,
-	},
+	}},
 }
 
 type Catalog struct{}
 
-func (c *Catalog) CreateCatalog(ctx context.Context, name string) (*http.Response, error) {
+func (c *Catalog) CreateCatalog(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error) {
+	properties, _ := params["properties"].(map[string]string)
+	comment, _ := params["comment"].(string)
 	body := CreateCatalogBody{
-		Name: name,
+		Name:       name,
+		Comment:    comment,
+		Properties: properties,
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -99,12 +105,12 @@ func (c *Catalog) ListCatalogs(ctx context.Context, params map[string]interface{
 
 		req, err := builder.Build(ctx, Host, Path, "")
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
@@ -112,10 +118,13 @@ func (c *Catalog) ListCatalogs(ctx context.Context, params map[string]interface{
 		var body struct {
 			NextPageToken string `json:"next_page_token"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -127,6 +136,11 @@ func (c *Catalog) ListCatalogs(ctx context.Context, params map[string]interface{
 }
 
 func (c *Catalog) ListSchemas(ctx context.Context, catalogName string, params map[string]interface{}) ([]*http.Response, error) {
+	// Unity schemas are flat, so there are no child namespaces to list
+	if _, ok := params["parentNamespace"]; ok {
+		return nil, common.ErrNotImplemented
+	}
+
 	pageToken, ok := params["pageToken"].(string)
 	if !ok {
 		pageToken = ""
@@ -154,22 +168,25 @@ func (c *Catalog) ListSchemas(ctx context.Context, catalogName string, params ma
 
 		req, err := builder.Build(ctx, Host, Path, "")
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
 		var body struct {
 			NextPageToken string `json:"next_page_token"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -207,12 +224,12 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 
 		req, err := builder.Build(ctx, Host, Path, "")
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
@@ -221,10 +238,13 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 			NextPageToken string `json:"next_page_token"`
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -237,12 +257,21 @@ func (c *Catalog) ListTables(ctx context.Context, catalogName string, schemaName
 	return responses, nil
 }
 
-func (c *Catalog) CreateSchema(ctx context.Context, catalogName string, name string) (*http.Response, error) {
+func (c *Catalog) CreateSchema(ctx context.Context, catalogName string, name string, params map[string]interface{}) (*http.Response, error) {
+	// The separator of a nested namespace would be read as the catalog/schema separator of a Unity full name
+	if strings.Contains(name, common.NamespaceSeparator) {
+		return nil, common.ErrNotImplemented
+	}
+	properties, ok := params["properties"].(map[string]string)
+	if !ok {
+		properties = map[string]string{}
+	}
+	comment, _ := params["comment"].(string)
 	body := CreateNamespaceBody{
 		Name:        name,
 		CatalogName: catalogName,
-		Comment:     "",
-		Properties:  map[string]string{},
+		Comment:     comment,
+		Properties:  properties,
 	}
 
 	jsonBody, _ := json.Marshal(body)
@@ -263,15 +292,24 @@ func (c *Catalog) DeleteSchema(ctx context.Context, catalogName string, schemaNa
 	return client.Do(req)
 }
 
-func (c *Catalog) CreateTable(ctx context.Context, name string, catalogName string, schemaName string) (*http.Response, error) {
+func (c *Catalog) CreateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error) {
 	body := CreateTableBody{
-		Name:             name,
+		Name:             tableName,
 		CatalogName:      catalogName,
 		SchemaName:       schemaName,
 		TableType:        "EXTERNAL",
 		DataSourceFormat: "DELTA",
 		StorageLocation:  "/",
 	}
+	if properties, ok := params["properties"].(map[string]string); ok {
+		body.Properties = properties
+	}
+	if comment, ok := params["comment"].(string); ok {
+		body.Comment = comment
+	}
+	if columns, ok := params["columns"].([]common.Column); ok {
+		body.Columns = columnInfos(columns)
+	}
 	jsonBody, _ := json.Marshal(body)
 
 	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint("/tables").SetJSONBody(jsonBody).Build(ctx, Host, Path, "")
@@ -332,7 +370,7 @@ func (c *Catalog) UpdateSchema(ctx context.Context, catalogName string, schemaNa
 	return client.Do(req)
 }
 
-func (c *Catalog) CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
+func (c *Catalog) CreateFunction(ctx context.Context, catalogName string, schemaName string, functionName string, params map[string]interface{}) (*http.Response, error) {
 	body := CreateFunctionBody{
 		Name:        functionName,
 		CatalogName: catalogName,
@@ -381,6 +419,14 @@ func (c *Catalog) DeleteFunction(ctx context.Context, catalogName string, schema
 	return client.Do(req)
 }
 
+func (c *Catalog) GetFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
+	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("/functions/%s.%s.%s", catalogName, schemaName, functionName)).Build(ctx, Host, Path, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
 func (c *Catalog) ListFunctions(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error) {
 	pageToken, ok := params["pageToken"].(string)
 	if !ok {
@@ -421,10 +467,13 @@ func (c *Catalog) ListFunctions(ctx context.Context, catalogName string, schemaN
 		var body struct {
 			NextPageToken string `json:"next_page_token"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
 			return nil, err
 		}
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -435,11 +484,13 @@ func (c *Catalog) ListFunctions(ctx context.Context, catalogName string, schemaN
 	return resposes, nil
 }
 
-func (c *Catalog) CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string) (*http.Response, error) {
+func (c *Catalog) CreateModel(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error) {
+	comment, _ := params["comment"].(string)
 	body := CreateModelBody{
 		Name:        modelName,
 		CatalogName: catalogName,
 		SchemaName:  schemaName,
+		Comment:     comment,
 	}
 
 	jsonBody, _ := json.Marshal(body)
@@ -494,12 +545,12 @@ func (c *Catalog) ListModels(ctx context.Context, catalogName string, schemaName
 
 		req, err := builder.Build(ctx, Host, Path, "")
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
@@ -507,11 +558,13 @@ func (c *Catalog) ListModels(ctx context.Context, catalogName string, schemaName
 		var body struct {
 			NextPageToken string `json:"next_page_token"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -538,7 +591,103 @@ func (c *Catalog) UpdateModel(ctx context.Context, catalogName string, schemaNam
 	return client.Do(req)
 }
 
-func (c *Catalog) CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string) (*http.Response, error) {
+func (c *Catalog) CreateModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) (*http.Response, error) {
+	runId, _ := params["runId"].(string)
+	body := CreateModelVersionBody{
+		CatalogName: catalogName,
+		SchemaName:  schemaName,
+		ModelName:   modelName,
+		Source:      fmt.Sprintf("file:///tmp/%s/%s/%s/%s", catalogName, schemaName, modelName, runId),
+		RunId:       runId,
+	}
+
+	jsonBody, _ := json.Marshal(body)
+
+	req, err := common.NewRequestBuilder().SetMethod("POST").SetEndpoint("/models/versions").SetJSONBody(jsonBody).Build(ctx, Host, Path, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) GetModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error) {
+	req, err := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("/models/%s.%s.%s/versions/%d", catalogName, schemaName, modelName, version)).Build(ctx, Host, Path, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) FinalizeModelVersion(ctx context.Context, catalogName string, schemaName string, modelName string, version int64) (*http.Response, error) {
+	fullName := fmt.Sprintf("%s.%s.%s", catalogName, schemaName, modelName)
+	body := FinalizeModelVersionBody{
+		FullName: fullName,
+		Version:  version,
+	}
+
+	jsonBody, _ := json.Marshal(body)
+
+	req, err := common.NewRequestBuilder().SetMethod("PATCH").SetEndpoint(fmt.Sprintf("/models/%s/versions/%d/finalize", fullName, version)).SetJSONBody(jsonBody).Build(ctx, Host, Path, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+func (c *Catalog) ListModelVersions(ctx context.Context, catalogName string, schemaName string, modelName string, params map[string]interface{}) ([]*http.Response, error) {
+	pageToken, ok := params["pageToken"].(string)
+	if !ok {
+		pageToken = ""
+	}
+
+	maxResults, ok := params["maxResults"].(int)
+	if !ok {
+		maxResults = 0
+	}
+
+	responses := make([]*http.Response, 0)
+
+	for {
+		builder := common.NewRequestBuilder().SetMethod("GET").SetEndpoint(fmt.Sprintf("/models/%s.%s.%s/versions", catalogName, schemaName, modelName))
+
+		if pageToken != "" {
+			builder.AddQueryParam("page_token", pageToken)
+		}
+		if maxResults != 0 {
+			builder.AddQueryParam("max_results", strconv.Itoa(maxResults))
+		}
+
+		req, err := builder.Build(ctx, Host, Path, "")
+		if err != nil {
+			return responses, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return responses, err
+		}
+
+		responses = append(responses, resp)
+
+		var body ListModelVersionsResponse
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
+		}
+
+		if body.NextPageToken == "" {
+			break
+		}
+
+		pageToken = body.NextPageToken
+	}
+	return responses, nil
+}
+
+func (c *Catalog) CreateVolume(ctx context.Context, catalogName string, schemaName string, volumeName string, params map[string]interface{}) (*http.Response, error) {
 	body := CreateVolumeBody{
 		Name:            volumeName,
 		CatalogName:     catalogName,
@@ -616,12 +765,12 @@ func (c *Catalog) ListVolumes(ctx context.Context, catalogName string, schemaNam
 
 		req, err := builder.Build(ctx, Host, Path, "")
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		resp, err := client.Do(req)
 		if err != nil {
-			return nil, err
+			return responses, err
 		}
 
 		responses = append(responses, resp)
@@ -630,10 +779,13 @@ func (c *Catalog) ListVolumes(ctx context.Context, catalogName string, schemaNam
 			NextPageToken string `json:"next_page_token"`
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-			return nil, err
+		jsonBody, err := common.ReadBody(resp)
+		if err != nil {
+			return responses, err
+		}
+		if err := json.Unmarshal(jsonBody, &body); err != nil {
+			return responses, err
 		}
-		resp.Body.Close()
 
 		if body.NextPageToken == "" {
 			break
@@ -645,46 +797,148 @@ func (c *Catalog) ListVolumes(ctx context.Context, catalogName string, schemaNam
 	return responses, nil
 }
 
-func (c *Catalog) CreatePrincipal(ctx context.Context, name string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) CreatePrincipal(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) GetPrincipal(ctx context.Context, name string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) UpdatePrincipal(ctx context.Context, name string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) DeletePrincipal(ctx context.Context, name string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) ListPrincipals(ctx context.Context, params map[string]interface{}) ([]*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
-func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) CreateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) GetView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) UpdateView(ctx context.Context, catalogName string, schemaName string, viewName string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) DeleteView(ctx context.Context, catalogName string, schemaName string, viewName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 func (c *Catalog) ListViews(ctx context.Context, catalogName string, schemaName string, params map[string]interface{}) ([]*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 
 func (c *Catalog) UpdateTable(ctx context.Context, catalogName string, schemaName string, tableName string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+	return nil, common.ErrNotImplemented
 }
 
-func (c *Catalog) GetFunction(ctx context.Context, catalogName string, schemaName string, functionName string) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) GrantPermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	return c.updatePermissionsCatalog(ctx, catalogName, params, true)
 }
 
-func (c *Catalog) GrantPermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
-	return nil, errors.New("not implemented")
+func (c *Catalog) RevokePermissionCatalog(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	return c.updatePermissionsCatalog(ctx, catalogName, params, false)
+}
+
+// updatePermissionsCatalog adds or removes a catalog privilege of the principal
+// in params["principal"], or of Principal if it is missing, as Unity grants
+// privileges to principals directly.
+func (c *Catalog) updatePermissionsCatalog(ctx context.Context, catalogName string, params map[string]interface{}, add bool) (*http.Response, error) {
+	privilege := params["privilege"].(string)
+	principal, ok := params["principal"].(string)
+	if !ok {
+		principal = Principal
+	}
+
+	change := PermissionsChange{
+		Principal: principal,
+		Add:       []string{},
+		Remove:    []string{},
+	}
+	if add {
+		change.Add = append(change.Add, privilege)
+	} else {
+		change.Remove = append(change.Remove, privilege)
+	}
+
+	jsonBody, _ := json.Marshal(UpdatePermissionsBody{Changes: []PermissionsChange{change}})
+
+	req, err := common.NewRequestBuilder().SetMethod("PATCH").SetEndpoint(fmt.Sprintf("/permissions/catalog/%s", catalogName)).SetJSONBody(jsonBody).Build(ctx, Host, Path, "")
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
 
+func (c *Catalog) CreateCatalogRole(ctx context.Context, catalogName string, roleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) CreatePrincipalRole(ctx context.Context, roleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) GrantCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) RevokeCatalogRole(ctx context.Context, principalRoleName string, catalogName string, catalogRoleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) GrantPrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+func (c *Catalog) RevokePrincipalRole(ctx context.Context, principalName string, principalRoleName string) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+
+func (c *Catalog) CommitTransaction(ctx context.Context, catalogName string, params map[string]interface{}) (*http.Response, error) {
+	return nil, common.ErrNotImplemented
+}
+
+// columnInfos converts generated columns into Unity columns, which describe
+// their type as a name, as SQL text and as a Spark JSON type.
+func columnInfos(columns []common.Column) []ColumnInfo {
+	infos := make([]ColumnInfo, 0, len(columns))
+	for i, column := range columns {
+		typeJson, _ := json.Marshal(sparkField(column))
+		infos = append(infos, ColumnInfo{
+			Name:     column.Name,
+			TypeName: strings.ToUpper(column.Type),
+			TypeText: typeText(column),
+			TypeJson: string(typeJson),
+			Position: i,
+			Nullable: true,
+		})
+	}
+	return infos
+}
+
+func typeText(column common.Column) string {
+	switch column.Type {
+	case "long":
+		return "bigint"
+	case "struct":
+		fields := make([]string, 0, len(column.Fields))
+		for _, field := range column.Fields {
+			fields = append(fields, fmt.Sprintf("%s:%s", field.Name, typeText(field)))
+		}
+		return fmt.Sprintf("struct<%s>", strings.Join(fields, ","))
+	default:
+		return column.Type
+	}
+}
+
+func sparkField(column common.Column) map[string]interface{} {
+	var sparkType interface{} = column.Type
+	if column.Type == "struct" {
+		fields := make([]interface{}, 0, len(column.Fields))
+		for _, field := range column.Fields {
+			fields = append(fields, sparkField(field))
+		}
+		sparkType = map[string]interface{}{"type": "struct", "fields": fields}
+	}
+	return map[string]interface{}{
+		"name":     column.Name,
+		"type":     sparkType,
+		"nullable": true,
+		"metadata": map[string]interface{}{},
+	}
 }
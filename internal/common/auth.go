@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,15 +10,29 @@ import (
 	"strings"
 )
 
+type tokenKey struct{}
+
+// WithToken returns a context whose requests authenticate with the given
+// token instead of the token of the catalog, e.g. to act as another principal.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
 func FetchPolarisToken() (string, error) {
 	id := os.Getenv("POLARIS_CLIENT_ID")
 	secret := os.Getenv("POLARIS_CLIENT_SECRET")
-	host := os.Getenv("POLARIS_HOST")
 
 	if id == "" || secret == "" {
 		return "", fmt.Errorf("client-id and client-secret variables must be set")
 	}
 
+	return FetchPolarisPrincipalToken(id, secret)
+}
+
+// FetchPolarisPrincipalToken fetches a token with the credentials of a principal.
+func FetchPolarisPrincipalToken(id string, secret string) (string, error) {
+	host := os.Getenv("POLARIS_HOST")
+
 	oauthURL := fmt.Sprintf("http://%s//api/catalog/v1/oauth/tokens", host)
 
 	form := url.Values{}
@@ -1,8 +1,10 @@
 package common
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -11,10 +13,39 @@ type Experiment struct {
 	Catalog        string        `json:"catalog"`
 	BenchmarkID    BenchmarkType `json:"benchmark"`
 	Threads        int           `json:"threads"`
+	Agents         []string      `json:"agents,omitempty"` // Agents the threads were distributed across
+	Seed           uint64        `json:"seed"`
 	StartTimestamp time.Time     `json:"start_timestamp"`
 	EndTimestamp   time.Time     `json:"end_timestamp"`
+	Anchor         time.Time     `json:"anchor"`           // Wall-clock time at which the elapsed times of the logs are 0
+	Clocks         []ClockOffset `json:"clocks,omitempty"` // Measured clock offsets of the agents
 	Duration       time.Duration `json:"duration"`
 	Entity         EntityType    `json:"entity"`
+	Parent         EntityType    `json:"parent,omitempty"`
+	Populate       int           `json:"populate,omitempty"`
+	PageSize       int           `json:"page_size,omitempty"`
+	Depth          int           `json:"depth,omitempty"`
+	FanOut         int           `json:"fan_out,omitempty"`
+	Payload        *Payload      `json:"payload,omitempty"`
+	PopulateTree   *Tree         `json:"populate_tree,omitempty"`
+	Manifest       string        `json:"manifest,omitempty"`
+	Keys           int           `json:"keys,omitempty"`
+	Distribution   *Distribution `json:"distribution,omitempty"`
+	ThinkTime      *ThinkTime    `json:"think_time,omitempty"`
+	Rate           float64       `json:"rate,omitempty"`        // Target iterations per second of every thread
+	Summary        bool          `json:"summary,omitempty"`     // Latency and status codes were summarized per thread instead of logging every response body
+	BodySample     float64       `json:"body_sample,omitempty"` // Fraction of the successful responses logged with their body in summary mode
+	Principals     int           `json:"principals,omitempty"`
+	PrincipalRoles bool          `json:"principal_roles,omitempty"`
+}
+
+// ClockOffset is the offset of the clock of an agent from the clock of the
+// coordinator, measured before the experiment. The elapsed times that the agent
+// logs are corrected by the offset, but may still be off by the uncertainty.
+type ClockOffset struct {
+	Agent       string        `json:"agent"`
+	Offset      time.Duration `json:"offset"`      // Agent clock minus coordinator clock
+	Uncertainty time.Duration `json:"uncertainty"` // Half the round trip of the measurement
 }
 
 type BenchmarkType int
@@ -26,6 +57,20 @@ const (
 	UpdateBenchmark // Update the same entity across all threads
 	CreateDeleteListBenchmark
 	UpdateGetBenchmark
+	ConflictUpdateBenchmark // Read-modify-write the same entity across all threads, retrying on conflicts
+	PropertyUpdateBenchmark // Read-modify-write a distinct property per thread on the same entity
+	RaceCreateBenchmark     // Create the same entity name across all threads in each round
+	ParentChildBenchmark    // Create children while one thread deletes and re-creates their parent
+	RecreateBenchmark       // Drop and re-create the same entity name per thread
+	PaginationBenchmark     // List pre-populated entities with small pages while other threads create and delete
+	CommitTableBenchmark    // Commit snapshots to the same Iceberg table across all threads, retrying on conflicts
+	TransactionBenchmark    // Commit to several tables in one transaction while other threads read them
+	ViewVersionBenchmark    // Replace the current version of the same view while other threads read it
+	ModelVersionBenchmark   // Create and finalize versions of the same model across all threads
+	PermissionBenchmark     // Revoke and grant access with one thread while the others check it as another principal
+	NamespaceTreeBenchmark  // Build and tear down nested namespace trees below the same namespace per thread
+	ManifestReadBenchmark   // Load and list entities sampled from the manifest of a populated catalog
+	SkewedUpdateBenchmark   // Update entities chosen from a shared pool following a key distribution
 )
 
 const (
@@ -39,6 +84,53 @@ const (
 	VolumeEntity    EntityType = "volume"
 )
 
+// WorkerParams returns the experiment options that are passed on to every worker.
+func (e Experiment) WorkerParams() map[string]interface{} {
+	params := make(map[string]interface{})
+	if e.Parent != "" {
+		params["parent"] = string(e.Parent)
+	}
+	if e.Payload != nil {
+		params["payload"] = *e.Payload
+	}
+	if e.ThinkTime != nil {
+		params["thinkTime"] = *e.ThinkTime
+	}
+	if e.Rate > 0 {
+		params["rate"] = e.Rate
+	}
+	return params
+}
+
+// Tree is the shape of the catalog state that is populated before measurement.
+type Tree struct {
+	Catalogs int `json:"catalogs"`
+	Schemas  int `json:"schemas"` // Per catalog
+	Tables   int `json:"tables"`  // Per schema
+}
+
+// ParseTree parses a tree of the form catalogs x schemas x tables, e.g. "50x100x1000".
+func ParseTree(spec string) (Tree, error) {
+	tree := Tree{}
+	_, err := fmt.Sscanf(spec, "%dx%dx%d", &tree.Catalogs, &tree.Schemas, &tree.Tables)
+	if err != nil || tree.Catalogs < 0 || tree.Schemas < 0 || tree.Tables < 0 {
+		return Tree{}, fmt.Errorf("invalid tree %q, expected catalogs x schemas x tables such as 50x100x1000", spec)
+	}
+	return tree, nil
+}
+
+func (t Tree) Size() int {
+	return t.Catalogs + t.Catalogs*t.Schemas + t.Catalogs*t.Schemas*t.Tables
+}
+
+// NamespaceSeparator separates the levels of a nested namespace in a schema name.
+const NamespaceSeparator = "."
+
+// NamespacePath returns the schema name of a nested namespace.
+func NamespacePath(levels ...string) string {
+	return strings.Join(levels, NamespaceSeparator)
+}
+
 func GetEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
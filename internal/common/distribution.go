@@ -0,0 +1,128 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+const (
+	UniformDistribution = "uniform"
+	ZipfianDistribution = "zipfian"
+	HotspotDistribution = "hotspot"
+	LatestDistribution  = "latest"
+)
+
+// Distribution describes how workers choose their target from a pool of keys.
+type Distribution struct {
+	Type    string  `json:"type"`
+	Theta   float64 `json:"theta,omitempty"`    // Skew of the zipfian and latest distributions
+	HotOps  float64 `json:"hot_ops,omitempty"`  // Fraction of the operations on the hot keys
+	HotKeys float64 `json:"hot_keys,omitempty"` // Fraction of the keys that are hot
+}
+
+// ParseDistribution parses a distribution and its colon-separated parameters:
+// "uniform", "zipfian[:theta]", "hotspot[:ops:keys]" or "latest[:theta]".
+func ParseDistribution(spec string) (Distribution, error) {
+	parts := strings.Split(spec, ":")
+	values := make([]float64, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return Distribution{}, fmt.Errorf("invalid distribution %q: %w", spec, err)
+		}
+		values = append(values, value)
+	}
+
+	distribution := Distribution{Type: parts[0]}
+	switch {
+	case distribution.Type == UniformDistribution && len(values) == 0:
+	case (distribution.Type == ZipfianDistribution || distribution.Type == LatestDistribution) && len(values) <= 1:
+		distribution.Theta = 0.99
+		if len(values) == 1 {
+			distribution.Theta = values[0]
+		}
+		if distribution.Theta <= 0 || distribution.Theta >= 1 {
+			return Distribution{}, fmt.Errorf("invalid distribution %q, theta must be between 0 and 1", spec)
+		}
+	case distribution.Type == HotspotDistribution && (len(values) == 0 || len(values) == 2):
+		distribution.HotOps, distribution.HotKeys = 0.8, 0.2
+		if len(values) == 2 {
+			distribution.HotOps, distribution.HotKeys = values[0], values[1]
+		}
+		if distribution.HotOps < 0 || distribution.HotOps > 1 || distribution.HotKeys <= 0 || distribution.HotKeys > 1 {
+			return Distribution{}, fmt.Errorf("invalid distribution %q, fractions must be between 0 and 1", spec)
+		}
+	default:
+		return Distribution{}, fmt.Errorf("invalid distribution %q, expected uniform, zipfian[:theta], hotspot[:ops:keys] or latest[:theta]", spec)
+	}
+	return distribution, nil
+}
+
+// KeyChooser chooses keys from a pool of n keys following a distribution.
+type KeyChooser struct {
+	distribution Distribution
+	n            int
+	zeta2        float64
+	zetaN        float64
+	alpha        float64
+	eta          float64
+}
+
+func NewKeyChooser(distribution Distribution, n int) *KeyChooser {
+	c := &KeyChooser{distribution: distribution, n: n}
+	if distribution.Type == ZipfianDistribution || distribution.Type == LatestDistribution {
+		// Constants of the zipfian generator by Gray et al., as used by YCSB
+		theta := distribution.Theta
+		c.zeta2 = zeta(2, theta)
+		c.zetaN = zeta(n, theta)
+		c.alpha = 1 / (1 - theta)
+		c.eta = (1 - math.Pow(2/float64(n), 1-theta)) / (1 - c.zeta2/c.zetaN)
+	}
+	return c
+}
+
+func zeta(n int, theta float64) float64 {
+	sum := 0.0
+	for i := 1; i <= n; i++ {
+		sum += 1 / math.Pow(float64(i), theta)
+	}
+	return sum
+}
+
+// Next returns the index of the next key in [0, n). With the latest
+// distribution, the index is the rank of the key by recency of its last write,
+// where 0 is the most recently written key, which the caller keeps track of.
+func (c *KeyChooser) Next(rng *rand.Rand) int {
+	if c.n <= 1 {
+		return 0
+	}
+
+	switch c.distribution.Type {
+	case ZipfianDistribution, LatestDistribution:
+		return c.zipfian(rng)
+	case HotspotDistribution:
+		hot := max(1, int(float64(c.n)*c.distribution.HotKeys))
+		if hot >= c.n || rng.Float64() < c.distribution.HotOps {
+			return rng.IntN(hot)
+		}
+		return hot + rng.IntN(c.n-hot)
+	default:
+		return rng.IntN(c.n)
+	}
+}
+
+// zipfian returns a key where key 0 is the most popular.
+func (c *KeyChooser) zipfian(rng *rand.Rand) int {
+	u := rng.Float64()
+	uz := u * c.zetaN
+	if uz < 1 {
+		return 0
+	}
+	if uz < 1+math.Pow(0.5, c.distribution.Theta) {
+		return 1
+	}
+	return min(c.n-1, int(float64(c.n)*math.Pow(c.eta*u-c.eta+1, c.alpha)))
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Distribution
+		wantErr bool
+	}{
+		{spec: "uniform", want: Distribution{Type: UniformDistribution}},
+		{spec: "zipfian", want: Distribution{Type: ZipfianDistribution, Theta: 0.99}},
+		{spec: "zipfian:0.5", want: Distribution{Type: ZipfianDistribution, Theta: 0.5}},
+		{spec: "hotspot", want: Distribution{Type: HotspotDistribution, HotOps: 0.8, HotKeys: 0.2}},
+		{spec: "hotspot:0.9:0.1", want: Distribution{Type: HotspotDistribution, HotOps: 0.9, HotKeys: 0.1}},
+		{spec: "zipfian:1", wantErr: true},
+		{spec: "hotspot:0.9", wantErr: true},
+		{spec: "uniform:1", wantErr: true},
+		{spec: "latest", want: Distribution{Type: LatestDistribution, Theta: 0.99}},
+		{spec: "latest:0.5", want: Distribution{Type: LatestDistribution, Theta: 0.5}},
+		{spec: "latest:0", wantErr: true},
+		{spec: "latest:0.5:0.5", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			got, err := ParseDistribution(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseDistribution(%q) error = %v, want error %v", test.spec, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseDistribution(%q) = %+v, want %+v", test.spec, got, test.want)
+			}
+		})
+	}
+}
+
+func TestKeyChooser(t *testing.T) {
+	const keys = 100
+	const draws = 100000
+
+	tests := []struct {
+		name         string
+		distribution Distribution
+		check        func(t *testing.T, counts []int)
+	}{
+		{
+			name:         "uniform",
+			distribution: Distribution{Type: UniformDistribution},
+			check: func(t *testing.T, counts []int) {
+				for key, count := range counts {
+					if count < draws/keys/2 || count > draws/keys*2 {
+						t.Errorf("key %d drawn %d times, want about %d", key, count, draws/keys)
+					}
+				}
+			},
+		},
+		{
+			name:         "zipfian",
+			distribution: Distribution{Type: ZipfianDistribution, Theta: 0.99},
+			check: func(t *testing.T, counts []int) {
+				for key := 1; key < keys; key++ {
+					if counts[key] > counts[0] {
+						t.Errorf("key %d drawn %d times, more than key 0 with %d", key, counts[key], counts[0])
+					}
+				}
+				if counts[0] < counts[keys-1]*10 {
+					t.Errorf("key 0 drawn %d times, want far more than the last key with %d", counts[0], counts[keys-1])
+				}
+			},
+		},
+		{
+			name:         "latest",
+			distribution: Distribution{Type: LatestDistribution, Theta: 0.99},
+			check: func(t *testing.T, counts []int) {
+				// Ranks by recency, where rank 0 is the most recently written key
+				for rank := 1; rank < keys; rank++ {
+					if counts[rank] > counts[0] {
+						t.Errorf("rank %d drawn %d times, more than rank 0 with %d", rank, counts[rank], counts[0])
+					}
+				}
+				if counts[0] < counts[keys-1]*10 {
+					t.Errorf("rank 0 drawn %d times, want far more than the last rank with %d", counts[0], counts[keys-1])
+				}
+			},
+		},
+		{
+			name:         "hotspot",
+			distribution: Distribution{Type: HotspotDistribution, HotOps: 0.8, HotKeys: 0.2},
+			check: func(t *testing.T, counts []int) {
+				hot := 0
+				for _, count := range counts[:keys/5] {
+					hot += count
+				}
+				if hot < draws*75/100 || hot > draws*85/100 {
+					t.Errorf("hot keys drawn %d times, want about %d", hot, draws*80/100)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chooser := NewKeyChooser(test.distribution, keys)
+			rng := rand.New(rand.NewPCG(1, 2))
+			counts := make([]int, keys)
+			for range draws {
+				key := chooser.Next(rng)
+				if key < 0 || key >= keys {
+					t.Fatalf("key %d outside [0, %d)", key, keys)
+				}
+				counts[key]++
+			}
+			test.check(t, counts)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"time"
@@ -12,20 +13,34 @@ import (
 type RoutineBatchLogger struct {
 	ExperimentID string
 	TheadID      int
-	file         *os.File // Single file for each logging struct
+	Principal    string    // Principal the thread runs as, empty for the catalog's own credentials
+	Behind       bool      // Whether the current iteration started behind the schedule of the thread
+	Agent        string    // Agent the thread runs on, empty for the coordinator
+	Anchor       time.Time // Monotonic time of the experiment anchor that elapsed times are measured from
+	Summary      *Summary  // Counts the requests of the thread in summary mode, which drops most response bodies
+	BodySample   float64   // Fraction of the successful responses whose body is logged in summary mode
+	KeepBody     bool      // Whether the next responses are logged with their body in summary mode, for the consistency checks
+	file         *os.File  // Single file for each logging struct
 	buffer       []LogEntry
 	batchSize    int
 }
 
 type LogEntry struct {
-	Level        string `json:"level"`
-	ExperimentID string `json:"experiment_id"`
-	ThreadID     int    `json:"thread_id"`
-	Method       string `json:"method"`
-	StepID       int    `json:"step_id"`
-	Timestamp    string `json:"timestamp"`
-	StatusCode   int    `json:"status_code"`
-	Body         string `json:"body"`
+	Level        string  `json:"level"`
+	ExperimentID string  `json:"experiment_id"`
+	ThreadID     int     `json:"thread_id"`
+	Principal    string  `json:"principal,omitempty"`
+	Method       string  `json:"method"`
+	StepID       int     `json:"step_id"`
+	Timestamp    string  `json:"timestamp"`
+	ElapsedNs    int64   `json:"elapsed_ns"`         // Monotonic time since the anchor of the experiment when logged
+	StartNs      int64   `json:"start_ns,omitempty"` // Monotonic time since the anchor when the request started
+	Agent        string  `json:"agent,omitempty"`
+	StatusCode   int     `json:"status_code"`
+	RequestSize  int64   `json:"request_size,omitempty"`
+	LatencyMs    float64 `json:"latency_ms,omitempty"`
+	Behind       bool    `json:"behind_schedule,omitempty"`
+	Body         string  `json:"body"`
 }
 
 func NewRoutineBatchLogger(logDir string, experimentID string, theadID int, batchSize int) (*RoutineBatchLogger, error) {
@@ -44,6 +59,7 @@ func NewRoutineBatchLogger(logDir string, experimentID string, theadID int, batc
 	return &RoutineBatchLogger{
 		ExperimentID: experimentID,
 		TheadID:      theadID,
+		Anchor:       time.Now(),
 		file:         file,
 		buffer:       make([]LogEntry, 0, batchSize),
 		batchSize:    batchSize,
@@ -52,16 +68,36 @@ func NewRoutineBatchLogger(logDir string, experimentID string, theadID int, batc
 }
 
 func (l *RoutineBatchLogger) Log(level string, method string, stepID int, statusCode int, body string) {
-	l.buffer = append(l.buffer, LogEntry{
+	l.LogRequest(level, method, stepID, statusCode, nil, body)
+}
+
+// LogRequest logs a response together with the stats of its request.
+func (l *RoutineBatchLogger) LogRequest(level string, method string, stepID int, statusCode int, stats *RequestStats, body string) {
+	now := time.Now()
+	entry := LogEntry{
 		Level:        level,
 		Method:       method,
 		ExperimentID: l.ExperimentID,
 		ThreadID:     l.TheadID,
+		Principal:    l.Principal,
+		Behind:       l.Behind,
+		Agent:        l.Agent,
 		StepID:       stepID,
 		StatusCode:   statusCode,
 		Body:         body,
-		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
-	})
+		Timestamp:    now.UTC().Format(time.RFC3339Nano),
+		ElapsedNs:    now.Sub(l.Anchor).Nanoseconds(),
+	}
+	if stats != nil {
+		entry.RequestSize = stats.Size
+		entry.LatencyMs = float64(stats.Latency.Microseconds()) / 1000
+		entry.StartNs = stats.Start.Sub(l.Anchor).Nanoseconds()
+		// The summary counts the responses, of which only errors and a sample keep their body
+		if l.Summary != nil && level != "ERROR" && !l.KeepBody && rand.Float64() >= l.BodySample {
+			entry.Body = ""
+		}
+	}
+	l.buffer = append(l.buffer, entry)
 
 	if len(l.buffer) >= l.batchSize {
 		l.Flush()
@@ -96,12 +132,21 @@ func (l *RoutineBatchLogger) Flush() {
 }
 
 func (l *RoutineBatchLogger) Close() {
+	if l.Summary != nil {
+		summary, err := json.Marshal(l.Summary)
+		if err != nil {
+			panic(fmt.Sprintf("failed to marshal summary: %v", err))
+		}
+		l.Log("INFO", "SUMMARY", 0, 0, string(summary))
+	}
 	l.Flush()
 	l.file.Close()
 
 }
 
-func MergeLogs(logDir string, experimentID string) error {
+// MergeLogs merges the per-thread logs of an experiment into one log and, if
+// parquetOutput is set, converts the merged log to a Parquet file as well.
+func MergeLogs(logDir string, experimentID string, parquetOutput *ParquetOutput) error {
 	// Finds all the file of the jsonl type
 	files, err := filepath.Glob(filepath.Join(logDir, "*.jsonl"))
 	if err != nil {
@@ -139,6 +184,9 @@ func MergeLogs(logDir string, experimentID string) error {
 		}
 	}
 
+	if parquetOutput != nil {
+		return writeParquet(mergedFilename, *parquetOutput)
+	}
 	return nil
 }
 
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestObserver is told about every request sent with a context that carries it.
+type RequestObserver interface {
+	RequestStarted()
+	RequestFinished()
+}
+
+type observerKey struct{}
+
+// WithObserver returns a context whose requests are reported to the observer,
+// e.g. to count the requests of a thread that are in flight.
+func WithObserver(ctx context.Context, observer RequestObserver) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// ObservedTransport reports the requests whose context carries an observer
+// from when they are sent until their response headers arrive, and ends the
+// spans of the requests built by a RequestBuilder with their response.
+type ObservedTransport struct {
+	Base http.RoundTripper
+}
+
+func (t *ObservedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if observer, ok := req.Context().Value(observerKey{}).(RequestObserver); ok {
+		observer.RequestStarted()
+		defer observer.RequestFinished()
+	}
+	resp, err := t.Base.RoundTrip(req)
+	if stats := StatsOf(req); stats != nil && stats.Span != nil {
+		endAttempt(stats.Span, resp, err)
+	}
+	return resp, err
+}
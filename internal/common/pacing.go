@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+const (
+	FixedThinkTime       = "fixed"
+	UniformThinkTime     = "uniform"
+	ExponentialThinkTime = "exponential"
+)
+
+// ThinkTime is the pause of a thread between two iterations.
+type ThinkTime struct {
+	Type string        `json:"type"`
+	Mean time.Duration `json:"mean,omitempty"` // Pause of the fixed and mean of the exponential think time
+	Min  time.Duration `json:"min,omitempty"`  // Bounds of the uniform think time
+	Max  time.Duration `json:"max,omitempty"`
+}
+
+// ParseThinkTime parses "fixed:100ms", "uniform:50ms-200ms" or "exponential:100ms".
+func ParseThinkTime(spec string) (ThinkTime, error) {
+	thinkType, value, _ := strings.Cut(spec, ":")
+	thinkTime := ThinkTime{Type: thinkType}
+
+	var err error
+	switch thinkType {
+	case FixedThinkTime, ExponentialThinkTime:
+		thinkTime.Mean, err = time.ParseDuration(value)
+	case UniformThinkTime:
+		minValue, maxValue, _ := strings.Cut(value, "-")
+		if thinkTime.Min, err = time.ParseDuration(minValue); err == nil {
+			thinkTime.Max, err = time.ParseDuration(maxValue)
+		}
+	default:
+		err = fmt.Errorf("expected fixed:duration, uniform:min-max or exponential:mean")
+	}
+	if err != nil {
+		return ThinkTime{}, fmt.Errorf("invalid think time %q: %w", spec, err)
+	}
+	if thinkTime.Mean < 0 || thinkTime.Min < 0 || thinkTime.Max < thinkTime.Min {
+		return ThinkTime{}, fmt.Errorf("invalid think time %q", spec)
+	}
+	return thinkTime, nil
+}
+
+func (t ThinkTime) Draw(rng *rand.Rand) time.Duration {
+	switch t.Type {
+	case UniformThinkTime:
+		return t.Min + time.Duration(rng.Int64N(int64(t.Max-t.Min)+1))
+	case ExponentialThinkTime:
+		return time.Duration(rng.ExpFloat64() * float64(t.Mean))
+	default:
+		return t.Mean
+	}
+}
+
+// scheduleTolerance is how late an iteration may start before it counts as behind schedule.
+const scheduleTolerance = time.Millisecond
+
+// Schedule spaces the iterations of a thread at a target rate. A thread that
+// falls behind runs its late iterations back to back instead of skipping them.
+type Schedule struct {
+	Rate       float64 // Target iterations per second, 0 does not wait
+	Iterations int
+	Behind     int // Iterations that started later than scheduled
+	start      time.Time
+	interval   time.Duration
+	end        time.Time
+}
+
+func NewSchedule(rate float64) *Schedule {
+	s := &Schedule{Rate: rate, start: time.Now()}
+	if rate > 0 {
+		s.interval = time.Duration(float64(time.Second) / rate)
+	}
+	return s
+}
+
+// Wait blocks until the next iteration is due and reports whether it starts
+// behind schedule. Iterations are only counted while the context is not done.
+func (s *Schedule) Wait(ctx context.Context) bool {
+	var late time.Duration
+	if s.interval > 0 {
+		due := s.start.Add(time.Duration(s.Iterations) * s.interval)
+		if late = time.Since(due); late < 0 {
+			Sleep(ctx, -late)
+		}
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	s.Iterations++
+	if s.interval > 0 && late > scheduleTolerance {
+		s.Behind++
+		return true
+	}
+	return false
+}
+
+// Stop ends the schedule, so that the achieved rate does not include idle time afterwards.
+func (s *Schedule) Stop() {
+	s.end = time.Now()
+}
+
+// AchievedRate returns the iterations per second since the schedule started.
+func (s *Schedule) AchievedRate() float64 {
+	end := s.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return float64(s.Iterations) / end.Sub(s.start).Seconds()
+}
+
+// Sleep pauses for the duration or until the context is done.
+func Sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	tests := []struct {
+		name           string
+		rate           float64
+		startedAgo     time.Duration // Moves the start of the schedule into the past
+		cancelled      bool
+		waits          int
+		wantIterations int
+		wantBehind     int
+		wantMinElapsed time.Duration
+	}{
+		{name: "no rate", waits: 5, wantIterations: 5},
+		{name: "on schedule", rate: 100, waits: 5, wantIterations: 5, wantMinElapsed: 40 * time.Millisecond},
+		{name: "behind schedule", rate: 100, startedAgo: time.Second, waits: 5, wantIterations: 5, wantBehind: 5},
+		{name: "cancelled", rate: 100, cancelled: true, waits: 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if test.cancelled {
+				cancel()
+			}
+
+			schedule := NewSchedule(test.rate)
+			schedule.start = schedule.start.Add(-test.startedAgo)
+			start := time.Now()
+			behind := 0
+			for range test.waits {
+				if schedule.Wait(ctx) {
+					behind++
+				}
+			}
+			schedule.Stop()
+
+			if schedule.Iterations != test.wantIterations {
+				t.Errorf("Iterations = %d, want %d", schedule.Iterations, test.wantIterations)
+			}
+			if schedule.Behind != test.wantBehind || behind != test.wantBehind {
+				t.Errorf("Behind = %d and %d waits behind, want %d", schedule.Behind, behind, test.wantBehind)
+			}
+			if elapsed := time.Since(start); elapsed < test.wantMinElapsed {
+				t.Errorf("waits took %v, want at least %v", elapsed, test.wantMinElapsed)
+			}
+		})
+	}
+}
+
+func TestParseThinkTime(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    ThinkTime
+		wantErr bool
+	}{
+		{spec: "fixed:100ms", want: ThinkTime{Type: FixedThinkTime, Mean: 100 * time.Millisecond}},
+		{spec: "uniform:50ms-200ms", want: ThinkTime{Type: UniformThinkTime, Min: 50 * time.Millisecond, Max: 200 * time.Millisecond}},
+		{spec: "exponential:1s", want: ThinkTime{Type: ExponentialThinkTime, Mean: time.Second}},
+		{spec: "uniform:200ms-50ms", wantErr: true},
+		{spec: "fixed:-1s", wantErr: true},
+		{spec: "poisson:1s", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			got, err := ParseThinkTime(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseThinkTime(%q) error = %v, want error %v", test.spec, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseThinkTime(%q) = %+v, want %+v", test.spec, got, test.want)
+			}
+		})
+	}
+}
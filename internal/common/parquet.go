@@ -0,0 +1,139 @@
+package common
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/parquet-go/parquet-go"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// parquetBatchSize is the number of rows written to the Parquet file at once.
+const parquetBatchSize = 1000
+
+// ParquetOutput is the Parquet file of an experiment that MergeLogs writes
+// besides the merged log.
+type ParquetOutput struct {
+	Dir        string
+	Experiment Experiment // Embedded in the file metadata under the key "experiment"
+}
+
+// ParquetEntry is a row of the Parquet file of an experiment. Columns are only
+// ever added to it, so that queries keep working on older files.
+type ParquetEntry struct {
+	ExperimentID string    `parquet:"experiment_id,dict"`
+	Entity       string    `parquet:"entity,dict"`
+	ThreadID     int32     `parquet:"thread_id"`
+	StepID       int64     `parquet:"step_id"`
+	Level        string    `parquet:"level,dict"`
+	Method       string    `parquet:"method,dict"`
+	StatusCode   int32     `parquet:"status_code"`
+	Timestamp    time.Time `parquet:"timestamp,timestamp(nanosecond:utc)"`
+	ElapsedNs    int64     `parquet:"elapsed_ns"`
+	StartNs      *int64    `parquet:"start_ns,optional"`
+	LatencyMs    *float64  `parquet:"latency_ms,optional"`
+	RequestSize  *int64    `parquet:"request_size,optional"`
+	Principal    *string   `parquet:"principal,optional,dict"`
+	Agent        *string   `parquet:"agent,optional,dict"`
+	Behind       bool      `parquet:"behind_schedule"`
+	Body         string    `parquet:"body,zstd"`
+}
+
+// newParquetEntry converts a log entry. Fields that the log omits when empty
+// are null.
+func newParquetEntry(entry LogEntry, entity EntityType) (ParquetEntry, error) {
+	timestamp, err := time.Parse(time.RFC3339Nano, entry.Timestamp)
+	if err != nil {
+		return ParquetEntry{}, fmt.Errorf("invalid timestamp %q: %w", entry.Timestamp, err)
+	}
+
+	row := ParquetEntry{
+		ExperimentID: entry.ExperimentID,
+		Entity:       string(entity),
+		ThreadID:     int32(entry.ThreadID),
+		StepID:       int64(entry.StepID),
+		Level:        entry.Level,
+		Method:       entry.Method,
+		StatusCode:   int32(entry.StatusCode),
+		Timestamp:    timestamp,
+		ElapsedNs:    entry.ElapsedNs,
+		Behind:       entry.Behind,
+		Body:         entry.Body,
+	}
+	if entry.StartNs != 0 {
+		row.StartNs = &entry.StartNs
+	}
+	if entry.LatencyMs != 0 {
+		row.LatencyMs = &entry.LatencyMs
+	}
+	if entry.RequestSize != 0 {
+		row.RequestSize = &entry.RequestSize
+	}
+	if entry.Principal != "" {
+		row.Principal = &entry.Principal
+	}
+	if entry.Agent != "" {
+		row.Agent = &entry.Agent
+	}
+	return row, nil
+}
+
+// writeParquet converts the merged log of an experiment to a Parquet file in
+// the directory of the output.
+func writeParquet(logFilename string, output ParquetOutput) error {
+	experiment, err := json.Marshal(output.Experiment)
+	if err != nil {
+		return fmt.Errorf("failed to marshal experiment: %w", err)
+	}
+
+	logFile, err := os.Open(logFilename)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	if err := os.MkdirAll(output.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", output.Dir, err)
+	}
+	filename := filepath.Join(output.Dir, fmt.Sprintf("%s.parquet", output.Experiment.ID))
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := parquet.NewGenericWriter[ParquetEntry](file, parquet.KeyValueMetadata("experiment", string(experiment)))
+	rows := make([]ParquetEntry, 0, parquetBatchSize)
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("invalid log entry: %w", err)
+		}
+		row, err := newParquetEntry(entry, output.Experiment.Entity)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+		if len(rows) == parquetBatchSize {
+			if _, err := writer.Write(rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if _, err := writer.Write(rows); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	// The deferred close only cleans up after an error, as a failed close may lose the footer
+	return file.Close()
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewParquetEntry(t *testing.T) {
+	timestamp := time.Date(2025, 3, 1, 12, 0, 0, 123456789, time.UTC)
+	startNs := int64(1500)
+	latencyMs := 2.5
+	requestSize := int64(512)
+	principal := "principal"
+	agent := "127.0.0.1:7071"
+
+	tests := []struct {
+		name    string
+		entry   LogEntry
+		want    ParquetEntry
+		wantErr bool
+	}{
+		{
+			name: "request",
+			entry: LogEntry{Level: "INFO", ExperimentID: "e", ThreadID: 3, Principal: principal, Method: "POST", StepID: 7,
+				Timestamp: timestamp.Format(time.RFC3339Nano), ElapsedNs: 2000, StartNs: startNs, Agent: agent, StatusCode: 201,
+				RequestSize: requestSize, LatencyMs: latencyMs, Behind: true, Body: `{"name":"c"}`},
+			want: ParquetEntry{ExperimentID: "e", Entity: "catalog", ThreadID: 3, StepID: 7, Level: "INFO", Method: "POST",
+				StatusCode: 201, Timestamp: timestamp, ElapsedNs: 2000, StartNs: &startNs, LatencyMs: &latencyMs,
+				RequestSize: &requestSize, Principal: &principal, Agent: &agent, Behind: true, Body: `{"name":"c"}`},
+		},
+		{
+			name: "omitted fields are null",
+			entry: LogEntry{Level: "ERROR", ExperimentID: "e", Method: "NONE", Timestamp: timestamp.Format(time.RFC3339Nano),
+				ElapsedNs: 10, Body: "connection refused"},
+			want: ParquetEntry{ExperimentID: "e", Entity: "catalog", Level: "ERROR", Method: "NONE", Timestamp: timestamp,
+				ElapsedNs: 10, Body: "connection refused"},
+		},
+		{
+			name:  "offset timestamp",
+			entry: LogEntry{Level: "INFO", Method: "GET", Timestamp: "2025-03-01T14:00:00.123456789+02:00"},
+			want:  ParquetEntry{Entity: "catalog", Level: "INFO", Method: "GET", Timestamp: timestamp},
+		},
+		{
+			name:    "invalid timestamp",
+			entry:   LogEntry{Level: "INFO", Method: "GET", Timestamp: "yesterday"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newParquetEntry(test.entry, CatalogEntity)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("newParquetEntry() error = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			// Timestamps are compared as instants, as the Parquet column is UTC
+			if !got.Timestamp.Equal(test.want.Timestamp) {
+				t.Errorf("Timestamp = %v, want %v", got.Timestamp, test.want.Timestamp)
+			}
+			got.Timestamp, test.want.Timestamp = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("newParquetEntry() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
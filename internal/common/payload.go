@@ -0,0 +1,123 @@
+package common
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// Range is a number that is drawn uniformly from [Min, Max] for every payload.
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r Range) Draw(rng *rand.Rand) int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rng.IntN(r.Max-r.Min+1)
+}
+
+// Payload describes the size and shape of the entities that workers create and
+// update. Sizes that are not configured keep the minimal default payload.
+type Payload struct {
+	Columns         Range `json:"columns"`
+	Nesting         Range `json:"nesting"`       // Depth of the nested struct columns
+	Properties      Range `json:"properties"`    // Number of properties
+	PropertySize    Range `json:"property_size"` // Length of every property value
+	Comment         Range `json:"comment"`       // Length of the comment
+	Representations Range `json:"representations"`
+}
+
+// ParsePayload parses a comma-separated list of key=value pairs, where every
+// value is a number or a min-max range, e.g. "columns=10-100,nesting=2".
+func ParsePayload(spec string) (Payload, error) {
+	payload := Payload{}
+	ranges := map[string]*Range{
+		"columns":         &payload.Columns,
+		"nesting":         &payload.Nesting,
+		"properties":      &payload.Properties,
+		"property-size":   &payload.PropertySize,
+		"comment":         &payload.Comment,
+		"representations": &payload.Representations,
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		r, exists := ranges[key]
+		if !found || !exists {
+			return Payload{}, fmt.Errorf("invalid payload option %q", pair)
+		}
+
+		minValue, maxValue, isRange := strings.Cut(value, "-")
+		if !isRange {
+			maxValue = minValue
+		}
+		var err error
+		if r.Min, err = strconv.Atoi(minValue); err != nil {
+			return Payload{}, fmt.Errorf("invalid payload option %q: %w", pair, err)
+		}
+		if r.Max, err = strconv.Atoi(maxValue); err != nil {
+			return Payload{}, fmt.Errorf("invalid payload option %q: %w", pair, err)
+		}
+		if r.Min < 0 || r.Max < r.Min {
+			return Payload{}, fmt.Errorf("invalid payload option %q", pair)
+		}
+	}
+
+	return payload, nil
+}
+
+// Column is a generated table column, which is a struct if it has fields.
+type Column struct {
+	Name   string
+	Type   string // string, long, double, boolean or struct
+	Fields []Column
+}
+
+// Generate draws a new payload into the parameters that the catalog adapters
+// read: properties, comment, columns and representations.
+func (p Payload) Generate(rng *rand.Rand, params map[string]interface{}) {
+	if p.Properties.Max > 0 {
+		properties := make(map[string]string)
+		for i := range p.Properties.Draw(rng) {
+			properties[fmt.Sprintf("property-%d", i)] = randomString(rng, p.PropertySize.Draw(rng))
+		}
+		params["properties"] = properties
+	}
+	if p.Comment.Max > 0 {
+		params["comment"] = randomString(rng, p.Comment.Draw(rng))
+	}
+	if p.Columns.Max > 0 {
+		params["columns"] = generateColumns(rng, "c", p.Columns.Draw(rng), p.Nesting.Draw(rng))
+	}
+	if p.Representations.Max > 0 {
+		params["representations"] = p.Representations.Draw(rng)
+	}
+}
+
+// generateColumns cycles through the primitive types, every fourth column is a
+// struct of four columns while the nesting depth allows it.
+func generateColumns(rng *rand.Rand, prefix string, count int, nesting int) []Column {
+	types := []string{"string", "long", "double", "boolean"}
+	columns := make([]Column, count)
+	for i := range columns {
+		columns[i] = Column{Name: fmt.Sprintf("%s_%d", prefix, i), Type: types[i%len(types)]}
+		if nesting > 0 && i%len(types) == len(types)-1 {
+			columns[i].Type = "struct"
+			columns[i].Fields = generateColumns(rng, columns[i].Name, len(types), nesting-1)
+		}
+	}
+	return columns
+}
+
+func randomString(rng *rand.Rand, length int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[rng.IntN(len(letters))]
+	}
+	return string(b)
+}
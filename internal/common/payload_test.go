@@ -0,0 +1,117 @@
+package common
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPayloadGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload Payload
+		check   func(t *testing.T, params map[string]interface{})
+	}{
+		{
+			name:    "default payload",
+			payload: Payload{},
+			check: func(t *testing.T, params map[string]interface{}) {
+				if len(params) != 0 {
+					t.Errorf("params = %v, want none", params)
+				}
+			},
+		},
+		{
+			name:    "properties",
+			payload: Payload{Properties: Range{Min: 2, Max: 5}, PropertySize: Range{Min: 8, Max: 8}},
+			check: func(t *testing.T, params map[string]interface{}) {
+				properties := params["properties"].(map[string]string)
+				if len(properties) < 2 || len(properties) > 5 {
+					t.Errorf("%d properties, want 2-5", len(properties))
+				}
+				for key, value := range properties {
+					if len(value) != 8 {
+						t.Errorf("property %s has length %d, want 8", key, len(value))
+					}
+				}
+			},
+		},
+		{
+			name:    "comment",
+			payload: Payload{Comment: Range{Min: 10, Max: 20}},
+			check: func(t *testing.T, params map[string]interface{}) {
+				comment := params["comment"].(string)
+				if len(comment) < 10 || len(comment) > 20 {
+					t.Errorf("comment has length %d, want 10-20", len(comment))
+				}
+			},
+		},
+		{
+			name:    "nested columns",
+			payload: Payload{Columns: Range{Min: 8, Max: 8}, Nesting: Range{Min: 1, Max: 1}},
+			check: func(t *testing.T, params map[string]interface{}) {
+				columns := params["columns"].([]Column)
+				if len(columns) != 8 {
+					t.Fatalf("%d columns, want 8", len(columns))
+				}
+				for i, column := range columns {
+					wantStruct := i%4 == 3
+					if (column.Type == "struct") != wantStruct {
+						t.Errorf("column %s has type %s", column.Name, column.Type)
+					}
+					for _, field := range column.Fields {
+						if field.Type == "struct" {
+							t.Errorf("field %s is nested deeper than 1", field.Name)
+						}
+					}
+				}
+			},
+		},
+		{
+			name:    "representations",
+			payload: Payload{Representations: Range{Min: 1, Max: 3}},
+			check: func(t *testing.T, params map[string]interface{}) {
+				representations := params["representations"].(int)
+				if representations < 1 || representations > 3 {
+					t.Errorf("%d representations, want 1-3", representations)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 2))
+			for range 20 {
+				params := make(map[string]interface{})
+				test.payload.Generate(rng, params)
+				test.check(t, params)
+			}
+		})
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Payload
+		wantErr bool
+	}{
+		{spec: "columns=10-100,nesting=2", want: Payload{Columns: Range{Min: 10, Max: 100}, Nesting: Range{Min: 2, Max: 2}}},
+		{spec: "properties=0-50, property-size=64", want: Payload{Properties: Range{Min: 0, Max: 50}, PropertySize: Range{Min: 64, Max: 64}}},
+		{spec: "comment=5-1", wantErr: true},
+		{spec: "rows=10", wantErr: true},
+		{spec: "columns", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			got, err := ParsePayload(test.spec)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParsePayload(%q) error = %v, want error %v", test.spec, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParsePayload(%q) = %+v, want %+v", test.spec, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// SetupStream is the stream of the seed that the setup draws its names from,
+// threads draw from the stream of their thread ID.
+const SetupStream = math.MaxUint64
+
+// SeededRand returns a PRNG for a stream of the seed of an experiment, so that
+// the same seed and thread count repeat the same sequence of operations.
+func SeededRand(seed uint64, stream uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, stream))
+}
+
+// RandReader reads random bytes from a PRNG, e.g. to derive UUIDs from a seed.
+// Every byte is mixed with the salt, so that the UUIDs of two experiments with
+// the same seed differ.
+type RandReader struct {
+	Rand *rand.Rand
+	Salt uuid.UUID
+}
+
+func (r RandReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r.Rand.Uint32()) ^ r.Salt[i%len(r.Salt)]
+	}
+	return len(p), nil
+}
+
+// NewName returns a random UUID drawn from the PRNG and salted with the
+// experiment ID, so a re-run of a seed against the same catalog does not
+// collide with the entities of the earlier run.
+func NewName(rng *rand.Rand, experimentID string) string {
+	salt, _ := uuid.Parse(experimentID)
+	return uuid.Must(uuid.NewRandomFromReader(RandReader{Rand: rng, Salt: salt})).String()
+}
+
+// Names draws the names of the entities that are created outside of the
+// threads, e.g. by the setup, from a stream of the seed. The names only repeat
+// with the seed if they are drawn in the same order, i.e. not concurrently.
+type Names struct {
+	mu           sync.Mutex
+	rand         *rand.Rand
+	experimentID string
+}
+
+func NewNames(rng *rand.Rand, experimentID string) *Names {
+	return &Names{rand: rng, experimentID: experimentID}
+}
+
+// New returns the next name, like NewName.
+func (n *Names) New() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return NewName(n.rand, n.experimentID)
+}
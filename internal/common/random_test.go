@@ -0,0 +1,76 @@
+package common
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestSeededRand(t *testing.T) {
+	tests := []struct {
+		name     string
+		seeds    [2]uint64
+		streams  [2]uint64
+		wantSame bool
+	}{
+		{name: "same seed and stream", seeds: [2]uint64{42, 42}, streams: [2]uint64{1, 1}, wantSame: true},
+		{name: "other stream", seeds: [2]uint64{42, 42}, streams: [2]uint64{1, 2}},
+		{name: "other seed", seeds: [2]uint64{42, 43}, streams: [2]uint64{1, 1}},
+		{name: "setup stream", seeds: [2]uint64{42, 42}, streams: [2]uint64{0, SetupStream}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := SeededRand(test.seeds[0], test.streams[0])
+			b := SeededRand(test.seeds[1], test.streams[1])
+			same := true
+			for range 10 {
+				if a.Uint64() != b.Uint64() {
+					same = false
+				}
+			}
+			if same != test.wantSame {
+				t.Errorf("sequences equal = %v, want %v", same, test.wantSame)
+			}
+		})
+	}
+}
+
+func TestNewName(t *testing.T) {
+	experimentA := uuid.NewString()
+	experimentB := uuid.NewString()
+
+	tests := []struct {
+		name          string
+		experimentIDs [2]string
+		wantSame      bool
+	}{
+		{name: "same experiment", experimentIDs: [2]string{experimentA, experimentA}, wantSame: true},
+		{name: "other experiment", experimentIDs: [2]string{experimentA, experimentB}},
+		{name: "unsalted", experimentIDs: [2]string{"", ""}, wantSame: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewName(SeededRand(42, 0), test.experimentIDs[0])
+			b := NewName(SeededRand(42, 0), test.experimentIDs[1])
+			if (a == b) != test.wantSame {
+				t.Errorf("names %s and %s equal = %v, want %v", a, b, a == b, test.wantSame)
+			}
+			if name, err := uuid.Parse(a); err != nil || name.Version() != 4 {
+				t.Errorf("name %s is not a random UUID: %v", a, err)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	experimentID := uuid.NewString()
+	names := NewNames(SeededRand(42, SetupStream), experimentID)
+	rng := SeededRand(42, SetupStream)
+	for range 5 {
+		// The names continue the stream like NewName on the same PRNG
+		if got, want := names.New(), NewName(rng, experimentID); got != want {
+			t.Errorf("New() = %s, want %s", got, want)
+		}
+	}
+}
@@ -3,12 +3,22 @@ package common
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"path"
+	"time"
 )
 
+// ErrNotImplemented is returned by the catalog adapters for the operations
+// their catalog does not offer, without sending a request.
+var ErrNotImplemented = errors.New("not implemented")
+
 type RequestBuilder struct {
 	host     string
 	path     string
@@ -64,12 +74,30 @@ func (b *RequestBuilder) Build(ctx context.Context, host string, resource string
 		fullURL = fmt.Sprintf("%s?%s", fullURL, b.query.Encode())
 	}
 
+	// Every attempt is a span of its own, which the transport ends with the response
+	ctx, span := StartSpan(ctx, "HTTP "+b.method, trace.SpanKindClient,
+		attribute.String("http.request.method", b.method), attribute.String("url.full", fullURL))
+
+	stats := &RequestStats{Start: time.Now(), Size: int64(len(b.body)), Span: span}
+	ctx = context.WithValue(ctx, requestStatsKey{}, stats)
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotFirstResponseByte: func() {
+			stats.Latency = time.Since(stats.Start)
+		},
+	})
+
 	req, err := http.NewRequestWithContext(ctx, b.method, fullURL, bytes.NewBuffer(b.body))
 	if err != nil {
+		endAttempt(span, nil, err)
 		return nil, err
 	}
 
 	req.Header = b.headers
+	injectTraceContext(ctx, req.Header)
+
+	if override, ok := ctx.Value(tokenKey{}).(string); ok {
+		token = override
+	}
 
 	if token != "" {
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
@@ -77,3 +105,32 @@ func (b *RequestBuilder) Build(ctx context.Context, host string, resource string
 
 	return req, nil
 }
+
+// ReadBody reads the response body and replaces it with an in-memory copy, so
+// the response can still be logged after the caller has inspected it.
+func ReadBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// RequestStats is attached to every built request, so that its response can be
+// logged together with the size of the request body and the latency.
+type RequestStats struct {
+	Start   time.Time
+	Size    int64
+	Latency time.Duration // Until the first byte of the response arrived
+	Span    trace.Span    // Span of the attempt, ended by ObservedTransport
+}
+
+type requestStatsKey struct{}
+
+// StatsOf returns the stats of a request built by a RequestBuilder, or nil.
+func StatsOf(req *http.Request) *RequestStats {
+	if req == nil {
+		return nil
+	}
+	stats, _ := req.Context().Value(requestStatsKey{}).(*RequestStats)
+	return stats
+}
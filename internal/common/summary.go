@@ -0,0 +1,188 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/HdrHistogram/hdrhistogram-go"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Range and precision of the latency histograms, in microseconds
+const (
+	minLatencyUs      = 1
+	maxLatencyUs      = int64(time.Hour / time.Microsecond)
+	latencySigFigures = 3
+)
+
+// Summary counts the requests of a thread by method, in an HDR histogram of
+// their latency and by status code, instead of logging every response body.
+// The summaries of the threads are merged at the end of the experiment.
+type Summary struct {
+	latency  map[string]*hdrhistogram.Histogram
+	statuses map[string]map[int]int64
+}
+
+func NewSummary() *Summary {
+	return &Summary{latency: make(map[string]*hdrhistogram.Histogram), statuses: make(map[string]map[int]int64)}
+}
+
+// Record counts a request. Requests that failed without a response have status 0
+// and no latency.
+func (s *Summary) Record(method string, statusCode int, latency time.Duration) {
+	if s.statuses[method] == nil {
+		s.statuses[method] = make(map[int]int64)
+	}
+	s.statuses[method][statusCode]++
+	if latency <= 0 {
+		return
+	}
+	if s.latency[method] == nil {
+		s.latency[method] = hdrhistogram.New(minLatencyUs, maxLatencyUs, latencySigFigures)
+	}
+	// Latencies above the range count as the highest trackable latency
+	s.latency[method].RecordValue(min(max(latency.Microseconds(), minLatencyUs), maxLatencyUs))
+}
+
+// Merge adds the requests of another summary.
+func (s *Summary) Merge(other *Summary) {
+	for method, histogram := range other.latency {
+		if s.latency[method] == nil {
+			s.latency[method] = hdrhistogram.New(minLatencyUs, maxLatencyUs, latencySigFigures)
+		}
+		s.latency[method].Merge(histogram)
+	}
+	for method, statuses := range other.statuses {
+		if s.statuses[method] == nil {
+			s.statuses[method] = make(map[int]int64)
+		}
+		for status, count := range statuses {
+			s.statuses[method][status] += count
+		}
+	}
+}
+
+// encodedSummary is the body of the SUMMARY log entry of a thread.
+type encodedSummary struct {
+	Latency  map[string]string           `json:"latency"` // Compressed HDR histograms in microseconds
+	Statuses map[string]map[string]int64 `json:"statuses"`
+}
+
+func (s *Summary) MarshalJSON() ([]byte, error) {
+	encoded := encodedSummary{Latency: make(map[string]string), Statuses: make(map[string]map[string]int64)}
+	for method, histogram := range s.latency {
+		data, err := histogram.Encode(hdrhistogram.V2CompressedEncodingCookieBase)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Latency[method] = string(data)
+	}
+	for method, statuses := range s.statuses {
+		encoded.Statuses[method] = make(map[string]int64)
+		for status, count := range statuses {
+			encoded.Statuses[method][strconv.Itoa(status)] = count
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+func (s *Summary) UnmarshalJSON(data []byte) error {
+	var encoded encodedSummary
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	*s = *NewSummary()
+	for method, data := range encoded.Latency {
+		histogram, err := hdrhistogram.Decode([]byte(data))
+		if err != nil {
+			return fmt.Errorf("invalid latency histogram of %s: %w", method, err)
+		}
+		s.latency[method] = histogram
+	}
+	for method, statuses := range encoded.Statuses {
+		s.statuses[method] = make(map[int]int64)
+		for status, count := range statuses {
+			code, err := strconv.Atoi(status)
+			if err != nil {
+				return fmt.Errorf("invalid status code %q of %s", status, method)
+			}
+			s.statuses[method][code] = count
+		}
+	}
+	return nil
+}
+
+// MethodSummary is the merged latency and status counts of the requests of a method.
+type MethodSummary struct {
+	Method   string           `json:"method"`
+	Requests int64            `json:"requests"`
+	P50Ms    float64          `json:"p50_ms"`
+	P90Ms    float64          `json:"p90_ms"`
+	P99Ms    float64          `json:"p99_ms"`
+	P999Ms   float64          `json:"p999_ms"`
+	MaxMs    float64          `json:"max_ms"`
+	MeanMs   float64          `json:"mean_ms"`
+	Statuses map[string]int64 `json:"statuses"`
+}
+
+// Methods returns the summaries of the methods, sorted by method.
+func (s *Summary) Methods() []MethodSummary {
+	methods := make([]string, 0, len(s.statuses))
+	for method := range s.statuses {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+
+	summaries := make([]MethodSummary, 0, len(methods))
+	for _, method := range methods {
+		summary := MethodSummary{Method: method, Statuses: make(map[string]int64)}
+		for status, count := range s.statuses[method] {
+			summary.Requests += count
+			summary.Statuses[strconv.Itoa(status)] = count
+		}
+		if histogram := s.latency[method]; histogram != nil {
+			summary.P50Ms = float64(histogram.ValueAtPercentile(50)) / 1000
+			summary.P90Ms = float64(histogram.ValueAtPercentile(90)) / 1000
+			summary.P99Ms = float64(histogram.ValueAtPercentile(99)) / 1000
+			summary.P999Ms = float64(histogram.ValueAtPercentile(99.9)) / 1000
+			summary.MaxMs = float64(histogram.Max()) / 1000
+			summary.MeanMs = histogram.Mean() / 1000
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// MergeSummaries merges the SUMMARY entries of the threads in a merged log.
+func MergeSummaries(filename string) (*Summary, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	merged := NewSummary()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		// Skips the other entries without decoding them
+		if !bytes.Contains(line, []byte(`"method":"SUMMARY"`)) {
+			continue
+		}
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		var summary Summary
+		if err := json.Unmarshal([]byte(entry.Body), &summary); err != nil {
+			return nil, fmt.Errorf("invalid summary of thread %d: %w", entry.ThreadID, err)
+		}
+		merged.Merge(&summary)
+	}
+	return merged, scanner.Err()
+}
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// request is a request recorded in a summary.
+type request struct {
+	method     string
+	statusCode int
+	latency    time.Duration
+}
+
+func summaryOf(requests []request) *Summary {
+	summary := NewSummary()
+	for _, r := range requests {
+		summary.Record(r.method, r.statusCode, r.latency)
+	}
+	return summary
+}
+
+func TestSummaryMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads [][]request
+	}{
+		{name: "no threads"},
+		{name: "one thread", threads: [][]request{
+			{{"GET", 200, time.Millisecond}, {"GET", 404, 2 * time.Millisecond}},
+		}},
+		{name: "same methods", threads: [][]request{
+			{{"GET", 200, time.Millisecond}, {"POST", 201, 5 * time.Millisecond}},
+			{{"GET", 200, 3 * time.Millisecond}, {"POST", 409, 10 * time.Millisecond}},
+		}},
+		{name: "other methods", threads: [][]request{
+			{{"GET", 200, time.Millisecond}},
+			{{"DELETE", 204, 2 * time.Millisecond}},
+		}},
+		{name: "failed without response", threads: [][]request{
+			{{"NONE", 0, 0}},
+			{{"GET", 200, time.Millisecond}, {"NONE", 0, 0}},
+		}},
+		{name: "latency out of range", threads: [][]request{
+			{{"GET", 200, time.Nanosecond}},
+			{{"GET", 200, 2 * time.Hour}},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Merging the summaries of the threads counts the same as one summary of all requests
+			var all []request
+			merged := NewSummary()
+			decoded := NewSummary()
+			for _, requests := range test.threads {
+				all = append(all, requests...)
+				summary := summaryOf(requests)
+				merged.Merge(summary)
+
+				// As the summaries are logged and merged from the log
+				data, err := json.Marshal(summary)
+				if err != nil {
+					t.Fatalf("failed to encode the summary: %v", err)
+				}
+				var thread Summary
+				if err := json.Unmarshal(data, &thread); err != nil {
+					t.Fatalf("failed to decode the summary %s: %v", data, err)
+				}
+				decoded.Merge(&thread)
+			}
+
+			want := summaryOf(all).Methods()
+			if got := merged.Methods(); !reflect.DeepEqual(got, want) {
+				t.Errorf("merged summary = %+v, want %+v", got, want)
+			}
+			if got := decoded.Methods(); !reflect.DeepEqual(got, want) {
+				t.Errorf("merged decoded summary = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeSummaries(t *testing.T) {
+	threads := [][]request{
+		{{"GET", 200, time.Millisecond}, {"POST", 201, 5 * time.Millisecond}},
+		{{"GET", 500, 3 * time.Millisecond}},
+	}
+
+	var log strings.Builder
+	var all []request
+	for threadID, requests := range threads {
+		all = append(all, requests...)
+		body, err := json.Marshal(summaryOf(requests))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range []LogEntry{
+			{Level: "INFO", ThreadID: threadID, Method: "GET", StatusCode: 200, Body: `{"method":"SUMMARY"}`},
+			{Level: "INFO", ThreadID: threadID, Method: "SUMMARY", Body: string(body)},
+		} {
+			line, _ := json.Marshal(entry)
+			log.Write(append(line, '\n'))
+		}
+	}
+	filename := filepath.Join(t.TempDir(), "merged.jsonl")
+	if err := os.WriteFile(filename, []byte(log.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := MergeSummaries(filename)
+	if err != nil {
+		t.Fatalf("MergeSummaries() error = %v", err)
+	}
+	if got, want := merged.Methods(), summaryOf(all).Methods(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSummaries() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// Attributes of the spans of the threads
+const (
+	ExperimentIDAttribute = attribute.Key("benchmark.experiment.id")
+	ThreadIDAttribute     = attribute.Key("benchmark.thread.id")
+	StepIDAttribute       = attribute.Key("benchmark.step.id")
+	EntityNameAttribute   = attribute.Key("benchmark.entity.name")
+)
+
+// tracer creates spans with the global tracer provider, which drops them
+// unless tracing has been set up.
+var tracer = otel.Tracer("benchmark")
+
+// SpanAttributes returns the attributes of a span when it starts.
+type SpanAttributes func() []attribute.KeyValue
+
+type spanAttributesKey struct{}
+
+// WithSpanAttributes returns a context whose spans start with the attributes,
+// e.g. the thread and the current step of a worker.
+func WithSpanAttributes(ctx context.Context, attributes SpanAttributes) context.Context {
+	return context.WithValue(ctx, spanAttributesKey{}, attributes)
+}
+
+// StartSpan starts a span with the attributes of the context as a child of the
+// span of the context.
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if spanAttributes, ok := ctx.Value(spanAttributesKey{}).(SpanAttributes); ok {
+		attributes = append(spanAttributes(), attributes...)
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// injectTraceContext adds the W3C traceparent header of the span of the context
+// to the request, so that the catalog can continue the trace.
+func injectTraceContext(ctx context.Context, header http.Header) {
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// endAttempt ends the span of a request attempt with its outcome.
+func endAttempt(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	span.End()
+}
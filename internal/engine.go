@@ -3,6 +3,8 @@ package internal
 import (
 	"benchmark/internal/common"
 	"context"
+	"log"
+	"math"
 	"net/http"
 	"sync"
 	"time"
@@ -12,6 +14,13 @@ type WorkerConfig struct {
 	WorkerFunc WorkerFunc
 	Threads    int
 	Params     map[string]interface{}
+	Audit      bool // Runs the worker function once after all other workers have stopped
+}
+
+// Principal is a caller that worker threads can run as instead of the catalog's own credentials.
+type Principal struct {
+	Name  string
+	Token string
 }
 
 type BenchmarkEngine struct {
@@ -19,6 +28,17 @@ type BenchmarkEngine struct {
 	threads      int
 	duration     time.Duration
 	Catalog      Catalog
+	Principals   []Principal        // Threads are assigned these principals in turn, audits keep the catalog's credentials
+	Seed         uint64             // Every thread draws its random choices from the stream of its thread ID
+	ThreadOffset int                // First thread ID, so that the threads of several agents get distinct IDs
+	LogDir       string             // Directory of the per-thread logs
+	Anchor       time.Time          // Monotonic time that the logs measure elapsed times from
+	Agent        string             // Agent the engine runs on, empty for the coordinator
+	Monitor      *Monitor           // Counts the requests of the threads for the dashboard, if set
+	Metrics      *ExperimentMetrics // Counts the requests of the threads for Prometheus, if set
+	Entity       common.EntityType  // Entity type of the experiment, named by the spans of the operations
+	Summary      bool               // Summarizes the requests of every thread instead of logging every response body
+	BodySample   float64            // Fraction of the successful responses logged with their body in summary mode
 	client       *http.Client
 }
 
@@ -28,6 +48,8 @@ func NewBenchmarkEngine(experimentID string, catalog Catalog, threads int, durat
 		Catalog:      catalog,
 		threads:      threads,
 		duration:     duration,
+		LogDir:       "./output/logs/tmp",
+		Anchor:       time.Now(),
 		client: &http.Client{
 			Timeout: time.Second * 30,
 			Transport: &http.Transport{
@@ -43,25 +65,65 @@ func NewBenchmarkEngine(experimentID string, catalog Catalog, threads int, durat
 }
 
 func (e *BenchmarkEngine) RunBenchmark(ctx context.Context, workers []WorkerConfig) error {
-	ctx, cancel := context.WithTimeout(ctx, e.duration)
+	threadAllocated := e.RunWorkers(ctx, workers)
+	return e.RunAudits(ctx, workers, threadAllocated)
+}
+
+// RunWorkers runs the threads of all workers but the audits for the duration
+// of the benchmark, and returns the thread ID that follows the last thread.
+func (e *BenchmarkEngine) RunWorkers(ctx context.Context, workers []WorkerConfig) int {
+	benchCtx, cancel := context.WithTimeout(ctx, e.duration)
 	defer cancel()
 	var wg sync.WaitGroup
-	threadAllocated := 0
+	threadAllocated := e.ThreadOffset
+	var schedulesMu sync.Mutex
+	schedules := make([]*common.Schedule, 0, e.threads)
+	if e.Monitor != nil {
+		e.Monitor.Start(e.duration)
+	}
 
 	for _, worker := range workers {
+		if worker.Audit {
+			continue
+		}
 		for t := 0; t < worker.Threads; t++ {
 			threadID := threadAllocated
 			wg.Add(1)
 
 			go func(threadID int, config WorkerConfig) {
 				defer wg.Done()
-				logger, _ := common.NewRoutineBatchLogger("./output/logs/tmp", e.ExperimentID, threadID, 20)
+				logger, _ := common.NewRoutineBatchLogger(e.LogDir, e.ExperimentID, threadID, 20)
 				defer logger.Close()
+				logger.Anchor = e.Anchor
+				logger.Agent = e.Agent
+				e.summarize(logger)
 
 				w := NewWorker(
 					e.client, e.Catalog, logger, config.Params, config.WorkerFunc)
+				w.Rand = common.SeededRand(e.Seed, uint64(threadID))
+				w.Monitor = e.Monitor
+				w.Metrics = e.Metrics
+				w.Entity = e.Entity
+
+				threadCtx := benchCtx
+				if len(e.Principals) > 0 {
+					principal := e.Principals[threadID%len(e.Principals)]
+					logger.Principal = principal.Name
+					threadCtx = common.WithToken(threadCtx, principal.Token)
+				}
+				if e.Metrics != nil {
+					threadCtx = common.WithObserver(threadCtx, e.Metrics)
+				}
 
-				w.Run(ctx)
+				if e.Monitor != nil {
+					e.Monitor.ActiveThreads.Add(1)
+					defer e.Monitor.ActiveThreads.Add(-1)
+				}
+				w.Run(threadCtx)
+
+				schedulesMu.Lock()
+				schedules = append(schedules, w.Schedule)
+				schedulesMu.Unlock()
 
 			}(threadID, worker)
 			threadAllocated++
@@ -69,5 +131,73 @@ func (e *BenchmarkEngine) RunBenchmark(ctx context.Context, workers []WorkerConf
 	}
 
 	wg.Wait()
+	reportSchedules(schedules)
+	return threadAllocated
+}
+
+// RunAudits runs the audits of the workers with thread IDs from threadID on.
+// Audits inspect the final state, so they run sequentially on their own thread IDs.
+func (e *BenchmarkEngine) RunAudits(ctx context.Context, workers []WorkerConfig, threadID int) error {
+	for _, audit := range workers {
+		if !audit.Audit {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.runAudit(ctx, threadID, audit)
+		threadID++
+	}
 	return nil
 }
+
+// reportSchedules logs the achieved rate of the threads against their target rate.
+func reportSchedules(schedules []*common.Schedule) {
+	if len(schedules) == 0 {
+		return
+	}
+
+	iterations, behind := 0, 0
+	achieved, target := 0.0, 0.0
+	minRate, maxRate := math.Inf(1), 0.0
+	for _, schedule := range schedules {
+		rate := schedule.AchievedRate()
+		iterations += schedule.Iterations
+		behind += schedule.Behind
+		achieved += rate
+		target += schedule.Rate
+		minRate = min(minRate, rate)
+		maxRate = max(maxRate, rate)
+	}
+
+	if target > 0 {
+		log.Printf("Achieved %.1f of %.1f iterations/s over %d threads (%.2f-%.2f per thread), %d of %d iterations behind schedule",
+			achieved, target, len(schedules), minRate, maxRate, behind, iterations)
+		return
+	}
+	log.Printf("Achieved %.1f iterations/s over %d threads (%.2f-%.2f per thread)", achieved, len(schedules), minRate, maxRate)
+}
+
+// summarize sets up the logger of a thread for summary mode.
+func (e *BenchmarkEngine) summarize(logger *common.RoutineBatchLogger) {
+	if e.Summary {
+		logger.Summary = common.NewSummary()
+		logger.BodySample = e.BodySample
+	}
+}
+
+func (e *BenchmarkEngine) runAudit(ctx context.Context, threadID int, config WorkerConfig) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	logger, _ := common.NewRoutineBatchLogger(e.LogDir, e.ExperimentID, threadID, 20)
+	defer logger.Close()
+	logger.Anchor = e.Anchor
+	logger.Agent = e.Agent
+	e.summarize(logger)
+
+	w := NewWorker(e.client, e.Catalog, logger, config.Params, config.WorkerFunc)
+	w.Rand = common.SeededRand(e.Seed, uint64(threadID))
+	w.Entity = e.Entity
+	w.RunOperation(ctx)
+}
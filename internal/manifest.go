@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"benchmark/internal/common"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"os"
+)
+
+// ManifestEntry names one populated entity, its level follows from the
+// deepest name that is set.
+type ManifestEntry struct {
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema,omitempty"`
+	Table   string `json:"table,omitempty"`
+}
+
+// Manifest lists the entities created by the populate phase, so that
+// workloads can sample from a realistically sized catalog state.
+type Manifest struct {
+	Catalogs []ManifestEntry
+	Schemas  []ManifestEntry
+	Tables   []ManifestEntry
+}
+
+// LoadManifest reads a JSONL manifest, a missing file is an empty manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	manifest := &Manifest{}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry ManifestEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		manifest.Add(entry)
+	}
+	return manifest, scanner.Err()
+}
+
+func (m *Manifest) Add(entry ManifestEntry) {
+	switch {
+	case entry.Table != "":
+		m.Tables = append(m.Tables, entry)
+	case entry.Schema != "":
+		m.Schemas = append(m.Schemas, entry)
+	default:
+		m.Catalogs = append(m.Catalogs, entry)
+	}
+}
+
+// Entries returns the entities of the given type.
+func (m *Manifest) Entries(entity common.EntityType) []ManifestEntry {
+	switch entity {
+	case common.CatalogEntity:
+		return m.Catalogs
+	case common.SchemaEntity:
+		return m.Schemas
+	case common.TableEntity:
+		return m.Tables
+	}
+	return nil
+}
+
+// Sample returns a random entity of the given type.
+func (m *Manifest) Sample(rng *rand.Rand, entity common.EntityType) (ManifestEntry, bool) {
+	entries := m.Entries(entity)
+	if len(entries) == 0 {
+		return ManifestEntry{}, false
+	}
+	return entries[rng.IntN(len(entries))], true
+}
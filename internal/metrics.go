@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+// Metrics are the Prometheus metrics of the requests of all experiments of the process.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	retries  *prometheus.CounterVec
+	timeouts *prometheus.CounterVec
+}
+
+// NewMetrics creates the metrics and registers them with the registerer.
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	labels := []string{"catalog", "entity", "method", "status"}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "benchmark_requests_total",
+			Help: "Requests sent to the catalog, by status code, where 0 is a request that failed without a response.",
+		}, labels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "benchmark_request_duration_seconds",
+			Help:    "Time until the first byte of the response of the catalog arrived.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15),
+		}, labels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "benchmark_requests_in_flight",
+			Help: "Requests sent to the catalog that wait for their response.",
+		}, []string{"catalog", "entity"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "benchmark_retries_total",
+			Help: "Operations retried after a conflict.",
+		}, []string{"catalog", "entity"}),
+		timeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "benchmark_timeouts_total",
+			Help: "Requests that timed out before the benchmark ended.",
+		}, []string{"catalog", "entity"}),
+	}
+	registerer.MustRegister(m.requests, m.latency, m.inFlight, m.retries, m.timeouts)
+	return m
+}
+
+// For returns the metrics of the requests of an experiment.
+func (m *Metrics) For(catalog string, entity string) *ExperimentMetrics {
+	labels := prometheus.Labels{"catalog": catalog, "entity": entity}
+	return &ExperimentMetrics{
+		requests: m.requests.MustCurryWith(labels),
+		latency:  m.latency.MustCurryWith(labels).(*prometheus.HistogramVec),
+		inFlight: m.inFlight.With(labels),
+		retries:  m.retries.With(labels),
+		timeouts: m.timeouts.With(labels),
+	}
+}
+
+// ExperimentMetrics are the metrics of one catalog and entity. They observe
+// the requests of the threads for the in-flight gauge.
+type ExperimentMetrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+	retries  prometheus.Counter
+	timeouts prometheus.Counter
+}
+
+// Record counts a request. Requests that failed without a response have status 0
+// and no latency.
+func (m *ExperimentMetrics) Record(method string, statusCode int, latency time.Duration) {
+	status := strconv.Itoa(statusCode)
+	m.requests.WithLabelValues(method, status).Inc()
+	if latency > 0 {
+		m.latency.WithLabelValues(method, status).Observe(latency.Seconds())
+	}
+}
+
+func (m *ExperimentMetrics) Retry() {
+	m.retries.Inc()
+}
+
+func (m *ExperimentMetrics) Timeout() {
+	m.timeouts.Inc()
+}
+
+func (m *ExperimentMetrics) RequestStarted() {
+	m.inFlight.Inc()
+}
+
+func (m *ExperimentMetrics) RequestFinished() {
+	m.inFlight.Dec()
+}
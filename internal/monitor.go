@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// monitorWindow is the number of one-second buckets of the rolling statistics.
+const monitorWindow = 10
+
+// monitorLogLines is the number of log lines shown below the statistics.
+const monitorLogLines = 10
+
+// bucket holds the requests of one second.
+type bucket struct {
+	methods   map[string]int
+	statuses  map[int]int
+	latencies []time.Duration
+}
+
+func newBucket() *bucket {
+	return &bucket{methods: make(map[string]int), statuses: make(map[int]int)}
+}
+
+// Monitor counts the requests of all threads in memory, without any I/O, for
+// the live dashboard.
+type Monitor struct {
+	mu            sync.Mutex
+	current       *bucket
+	buckets       []*bucket // Completed seconds, the most recent last
+	ActiveThreads atomic.Int64
+	start         atomic.Pointer[time.Time]
+	duration      time.Duration
+	logs          []string // The last log lines, as the redraws clear the terminal
+}
+
+func NewMonitor() *Monitor {
+	return &Monitor{current: newBucket()}
+}
+
+// Start marks the start of the measurement, which lasts for the duration.
+func (m *Monitor) Start(duration time.Duration) {
+	m.duration = duration
+	start := time.Now()
+	m.start.Store(&start)
+}
+
+// Record counts a request. Requests that failed without a response have status 0.
+func (m *Monitor) Record(method string, statusCode int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.current.methods[method]++
+	m.current.statuses[statusCode]++
+	if latency > 0 {
+		m.current.latencies = append(m.current.latencies, latency)
+	}
+}
+
+// Write keeps the last lines of the log for the dashboard, so that the monitor
+// can be the output of the log while the dashboard is shown.
+func (m *Monitor) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, strings.Split(strings.TrimRight(string(p), "\n"), "\n")...)
+	if len(m.logs) > monitorLogLines {
+		m.logs = m.logs[len(m.logs)-monitorLogLines:]
+	}
+	return len(p), nil
+}
+
+// rotate completes the current second and returns the buckets of the window.
+func (m *Monitor) rotate() []*bucket {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.buckets = append(m.buckets, m.current)
+	if len(m.buckets) > monitorWindow {
+		m.buckets = m.buckets[1:]
+	}
+	m.current = newBucket()
+	return slices.Clone(m.buckets)
+}
+
+// Dashboard redraws the statistics on out every second until the context is
+// done, when it draws the last frame.
+func (m *Monitor) Dashboard(ctx context.Context, title string, out io.Writer) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			m.draw(title, out)
+			return
+		case <-ticker.C:
+			m.draw(title, out)
+		}
+	}
+}
+
+func (m *Monitor) draw(title string, out io.Writer) {
+	buckets := m.rotate()
+	m.mu.Lock()
+	logs := strings.Join(m.logs, "\n")
+	m.mu.Unlock()
+	// Clears the terminal and moves the cursor home before every frame
+	fmt.Fprint(out, "\033[H\033[2J"+title+"\n"+m.render(buckets)+"\n"+logs+"\n")
+}
+
+func (m *Monitor) render(buckets []*bucket) string {
+	var b strings.Builder
+
+	start := m.start.Load()
+	if start == nil {
+		b.WriteString("Setting up...\n")
+		return b.String()
+	}
+	elapsed := time.Since(*start).Truncate(time.Second)
+	remaining := max(0, m.duration-elapsed).Truncate(time.Second)
+	fmt.Fprintf(&b, "Elapsed %s, remaining %s, active threads %d\n\n", elapsed, remaining, m.ActiveThreads.Load())
+
+	// Throughput of the last second
+	last := buckets[len(buckets)-1]
+	methods := make([]string, 0, len(last.methods))
+	for method := range last.methods {
+		methods = append(methods, method)
+	}
+	slices.Sort(methods)
+	fmt.Fprintf(&b, "%-8s %10s\n", "Method", "ops/s")
+	for _, method := range methods {
+		fmt.Fprintf(&b, "%-8s %10d\n", method, last.methods[method])
+	}
+
+	// Errors and latency of the rolling window
+	requests, failed := 0, 0
+	statuses := make(map[int]int)
+	var latencies []time.Duration
+	for _, bucket := range buckets {
+		for status, count := range bucket.statuses {
+			statuses[status] += count
+			requests += count
+			if status < 200 || status > 299 {
+				failed += count
+			}
+		}
+		latencies = append(latencies, bucket.latencies...)
+	}
+
+	fmt.Fprintf(&b, "\nLast %ds: %d requests", len(buckets), requests)
+	if requests > 0 {
+		fmt.Fprintf(&b, ", error rate %.2f%%", 100*float64(failed)/float64(requests))
+	}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		fmt.Fprintf(&b, ", p50 %s, p99 %s", percentile(latencies, 0.5), percentile(latencies, 0.99))
+	}
+	b.WriteString("\n")
+
+	codes := make([]int, 0, len(statuses))
+	for status := range statuses {
+		codes = append(codes, status)
+	}
+	slices.Sort(codes)
+	fmt.Fprintf(&b, "%-8s %10s %8s\n", "Status", "requests", "rate")
+	for _, status := range codes {
+		label := fmt.Sprint(status)
+		if status == 0 {
+			label = "failed"
+		}
+		fmt.Fprintf(&b, "%-8s %10d %7.2f%%\n", label, statuses[status], 100*float64(statuses[status])/float64(requests))
+	}
+	return b.String()
+}
+
+// percentile returns the percentile of sorted latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	return latencies[int(p*float64(len(latencies)-1))].Round(10 * time.Microsecond)
+}
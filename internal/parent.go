@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"sync"
+)
+
+// ParentTracker records the deletes of a parent entity and the children that
+// were acknowledged under it, so an audit can tell which children must not
+// have survived a parent deletion.
+type ParentTracker struct {
+	mu            sync.Mutex
+	deletes       []bool          // Outcome of every parent delete, in the order they were sent
+	children      map[string]int  // Child name -> number of parent deletes sent before the child was acknowledged
+	gaps          int             // Number of times the parent was deleted successfully
+	absent        bool            // Whether the parent is deleted and its re-create has not been sent yet
+	withoutParent map[string]bool // Children that were created while the parent was deleted
+}
+
+func NewParentTracker() *ParentTracker {
+	return &ParentTracker{
+		deletes:       make([]bool, 0),
+		children:      make(map[string]int),
+		withoutParent: make(map[string]bool),
+	}
+}
+
+// BeginDelete registers a parent delete that is about to be sent and returns
+// its index for DeleteSucceeded.
+func (t *ParentTracker) BeginDelete() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deletes = append(t.deletes, false)
+	return len(t.deletes) - 1
+}
+
+func (t *ParentTracker) DeleteSucceeded(index int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deletes[index] = true
+	t.gaps++
+	t.absent = true
+}
+
+// BeginRecreate registers that the re-create of a deleted parent is about to be sent.
+func (t *ParentTracker) BeginRecreate() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.absent = false
+}
+
+// Gap returns the number of the current gap between a successful parent delete
+// and the re-create of the parent, or 0 if the parent may exist. A child create
+// records the gap when it is sent, for ChildCreated.
+func (t *ParentTracker) Gap() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.absent {
+		return 0
+	}
+	return t.gaps
+}
+
+// ChildCreated records an acknowledged child with the gap its create was sent
+// in. A child that was sent and acknowledged within the same gap was created
+// under a parent that did not exist.
+func (t *ParentTracker) ChildCreated(name string, gap int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.children[name] = len(t.deletes)
+	t.withoutParent[name] = gap != 0 && t.absent && gap == t.gaps
+}
+
+// CreatedWithoutParent reports whether the child was created while its parent
+// was deleted, i.e. it references a parent that was re-created after it.
+func (t *ParentTracker) CreatedWithoutParent(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.withoutParent[name]
+}
+
+// Orphaned reports whether a parent delete that was sent after the child had
+// been acknowledged succeeded, in which case the child must no longer exist.
+func (t *ParentTracker) Orphaned(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, succeeded := range t.deletes[t.children[name]:] {
+		if succeeded {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *ParentTracker) Children() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	names := make([]string, 0, len(t.children))
+	for name := range t.children {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,83 @@
+package internal
+
+import "testing"
+
+func TestParentTracker(t *testing.T) {
+	tests := []struct {
+		name          string
+		run           func(tracker *ParentTracker)
+		orphaned      bool
+		withoutParent bool
+	}{
+		{
+			name: "created without deletes",
+			run: func(tracker *ParentTracker) {
+				tracker.ChildCreated("child", tracker.Gap())
+			},
+		},
+		{
+			name: "deleted after creation",
+			run: func(tracker *ParentTracker) {
+				tracker.ChildCreated("child", tracker.Gap())
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+			},
+			orphaned: true,
+		},
+		{
+			name: "failed delete after creation",
+			run: func(tracker *ParentTracker) {
+				tracker.ChildCreated("child", tracker.Gap())
+				tracker.BeginDelete()
+			},
+		},
+		{
+			name: "created after re-create",
+			run: func(tracker *ParentTracker) {
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+				tracker.BeginRecreate()
+				tracker.ChildCreated("child", tracker.Gap())
+			},
+		},
+		{
+			name: "created while deleted",
+			run: func(tracker *ParentTracker) {
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+				tracker.ChildCreated("child", tracker.Gap())
+				tracker.BeginRecreate()
+			},
+			withoutParent: true,
+		},
+		{
+			name: "sent while deleted, acknowledged after re-create",
+			run: func(tracker *ParentTracker) {
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+				gap := tracker.Gap()
+				tracker.BeginRecreate()
+				tracker.ChildCreated("child", gap)
+			},
+		},
+		{
+			name: "sent in an earlier gap",
+			run: func(tracker *ParentTracker) {
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+				gap := tracker.Gap()
+				tracker.BeginRecreate()
+				tracker.DeleteSucceeded(tracker.BeginDelete())
+				tracker.ChildCreated("child", gap)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewParentTracker()
+			test.run(tracker)
+			if orphaned := tracker.Orphaned("child"); orphaned != test.orphaned {
+				t.Errorf("Orphaned() = %v, want %v", orphaned, test.orphaned)
+			}
+			if withoutParent := tracker.CreatedWithoutParent("child"); withoutParent != test.withoutParent {
+				t.Errorf("CreatedWithoutParent() = %v, want %v", withoutParent, test.withoutParent)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// PermissionTracker records the grants a churn thread toggles, so that the
+// access decisions of other threads can be compared with the grant state at
+// the time they were made.
+type PermissionTracker struct {
+	mu      sync.Mutex
+	version int             // Incremented whenever a change starts or ends
+	pending int             // Changes that were sent but not yet answered
+	granted map[string]bool // Grant -> whether it is currently held
+
+	Allowed      atomic.Int64
+	Denied       atomic.Int64
+	Transitional atomic.Int64 // Decisions that overlapped a change and are not classified
+	StaleAllowed atomic.Int64
+	StaleDenied  atomic.Int64
+}
+
+// NewPermissionTracker returns a tracker in which all given grants are held.
+func NewPermissionTracker(grants ...string) *PermissionTracker {
+	granted := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		granted[grant] = true
+	}
+	return &PermissionTracker{granted: granted}
+}
+
+func (t *PermissionTracker) BeginChange() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.version++
+	t.pending++
+}
+
+// EndChange records the outcome of a change, the grant state only changes if
+// the catalog acknowledged it.
+func (t *PermissionTracker) EndChange(grant string, granted bool, succeeded bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.version++
+	t.pending--
+	if succeeded {
+		t.granted[grant] = granted
+	}
+}
+
+func (t *PermissionTracker) Granted(grant string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.granted[grant]
+}
+
+// State returns the version of the grant state, whether access must be allowed
+// because all grants are held, and whether no change is in flight.
+func (t *PermissionTracker) State() (int, bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	allowed := true
+	for _, granted := range t.granted {
+		allowed = allowed && granted
+	}
+	return t.version, allowed, t.pending == 0
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// Race synchronizes a fixed number of racing threads into rounds. Every round
+// starts once all racers have arrived, and its results are handed to the last
+// racer that finishes it.
+type Race struct {
+	Racers int
+
+	mu       sync.Mutex
+	round    int
+	arrived  int
+	start    chan struct{}
+	finished map[int]map[int]int // Round -> thread ID -> status code
+}
+
+func NewRace(racers int) *Race {
+	return &Race{
+		Racers:   racers,
+		start:    make(chan struct{}),
+		finished: make(map[int]map[int]int),
+	}
+}
+
+// Start blocks until all racers have arrived and returns the round they are
+// about to race in. It returns false if the context ends first.
+func (r *Race) Start(ctx context.Context) (int, bool) {
+	r.mu.Lock()
+	round := r.round
+	start := r.start
+	r.arrived++
+	if r.arrived == r.Racers {
+		r.arrived = 0
+		r.round++
+		r.start = make(chan struct{})
+		close(start)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-start:
+		return round, true
+	case <-ctx.Done():
+		return round, false
+	}
+}
+
+// Finish records the status code of a racer. The last racer to finish the
+// round receives the status codes of all racers keyed by thread ID.
+func (r *Race) Finish(round int, threadID int, statusCode int) (map[int]int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results, exists := r.finished[round]
+	if !exists {
+		results = make(map[int]int, r.Racers)
+		r.finished[round] = results
+	}
+	results[threadID] = statusCode
+
+	if len(results) < r.Racers {
+		return nil, false
+	}
+	delete(r.finished, round)
+	return results, true
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// workerFuncs lists the worker functions that agents can run by name. A new
+// worker has to be added here to run in a distributed benchmark.
+var workerFuncs = []WorkerFunc{
+	CreateCatalogWorker,
+	CreatePrincipalWorker,
+	CreateSchemaWorker,
+	CreateTableWorker,
+	CreateViewWorker,
+	CreateFunctionWorker,
+	CreateModelWorker,
+	CreateVolumeWorker,
+	CreateDeleteCatalogWorker,
+	CreateDeletePrincipalWorker,
+	CreateDeleteSchemaWorker,
+	CreateDeleteTableWorker,
+	CreateDeleteViewWorker,
+	CreateDeleteFunctionWorker,
+	CreateDeleteModelWorker,
+	CreateDeleteVolumeWorker,
+	UpdateCatalogWorker,
+	UpdatePrincipalWorker,
+	UpdateSchemaWorker,
+	UpdateTableWorker,
+	UpdateViewWorker,
+	UpdateModelWorker,
+	UpdateVolumeWorker,
+	UpdateGetCatalogWorker,
+	UpdateGetPrincipalWorker,
+	UpdateGetSchemaWorker,
+	UpdateGetTableWorker,
+	UpdateGetViewWorker,
+	UpdateGetModelWorker,
+	UpdateGetVolumeWorker,
+	ConflictUpdateCatalogWorker,
+	ConflictUpdatePrincipalWorker,
+	PropertyUpdateCatalogWorker,
+	PropertyUpdateSchemaWorker,
+	PropertyUpdateTableWorker,
+	PropertyAuditCatalogWorker,
+	PropertyAuditSchemaWorker,
+	PropertyAuditTableWorker,
+	RaceCreateCatalogWorker,
+	RaceCreatePrincipalWorker,
+	RaceCreateSchemaWorker,
+	RaceCreateTableWorker,
+	ChurnParentWorker,
+	ParentChildTableWorker,
+	ParentChildViewWorker,
+	ParentChildFunctionWorker,
+	ParentChildVolumeWorker,
+	ParentChildAuditTableWorker,
+	ParentChildAuditViewWorker,
+	ParentChildAuditFunctionWorker,
+	ParentChildAuditVolumeWorker,
+	RecreateCatalogWorker,
+	RecreatePrincipalWorker,
+	RecreateSchemaWorker,
+	RecreateTableWorker,
+	RecreateViewWorker,
+	RecreateFunctionWorker,
+	RecreateModelWorker,
+	RecreateVolumeWorker,
+	PaginationListCatalogsWorker,
+	PaginationListSchemasWorker,
+	PaginationListTablesWorker,
+	PaginationListViewsWorker,
+	PaginationListFunctionsWorker,
+	PaginationListModelsWorker,
+	PaginationListVolumesWorker,
+	CommitTableWorker,
+	CommitAuditTableWorker,
+	TransactionWriterWorker,
+	TransactionReaderWorker,
+	TransactionAuditWorker,
+	ViewVersionWriterWorker,
+	ViewVersionReaderWorker,
+	ModelVersionWorker,
+	ModelVersionAuditWorker,
+	PermissionChurnWorker,
+	PermissionAccessWorker,
+	PermissionAuditWorker,
+	NamespaceTreeWorker,
+	ManifestReadCatalogWorker,
+	ManifestReadSchemaWorker,
+	ManifestReadTableWorker,
+	ListCatalogsWorker,
+	ListPrincipalsWorker,
+	ListSchemasWorker,
+	ListTablesWorker,
+	ListViewsWorker,
+	ListFunctionsWorker,
+	ListModelsWorker,
+	ListVolumesWorker,
+}
+
+var workersByName = func() map[string]WorkerFunc {
+	workers := make(map[string]WorkerFunc, len(workerFuncs))
+	for _, workerFunc := range workerFuncs {
+		workers[WorkerName(workerFunc)] = workerFunc
+	}
+	return workers
+}()
+
+// WorkerName returns the name of a worker function, which is the same in every
+// process of the same build.
+func WorkerName(workerFunc WorkerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(workerFunc).Pointer()).Name()
+}
+
+// LookupWorker returns the worker function with the given name.
+func LookupWorker(name string) (WorkerFunc, bool) {
+	workerFunc, exists := workersByName[name]
+	return workerFunc, exists
+}
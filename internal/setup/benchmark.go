@@ -2,8 +2,15 @@ package setup
 
 import (
 	"benchmark/internal"
+	"benchmark/internal/common"
 	"context"
-	"github.com/google/uuid"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
 )
 
 func grantPermissionCatalog(ctx context.Context, catalog internal.Catalog, catalogName string) error {
@@ -24,10 +31,19 @@ func grantPermissionCatalog(ctx context.Context, catalog internal.Catalog, catal
 	return nil
 }
 
-func createCatalog(ctx context.Context, catalog internal.Catalog) (string, error) {
-	catalogName := uuid.NewString()
+// grantBestEffort grants the table privileges on a catalog for the setups that
+// also run against catalogs without catalog roles, which only exist in Polaris.
+// A failed grant is logged instead of failing the setup.
+func grantBestEffort(ctx context.Context, catalog internal.Catalog, catalogName string) {
+	if err := grantPermissionCatalog(ctx, catalog, catalogName); err != nil {
+		log.Printf("Catalog %s keeps its default privileges: %v", catalogName, err)
+	}
+}
 
-	_, err := catalog.CreateCatalog(ctx, catalogName)
+func createCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names) (string, error) {
+	catalogName := names.New()
+
+	_, err := catalog.CreateCatalog(ctx, catalogName, nil)
 	if err != nil {
 		return "", err
 	}
@@ -35,10 +51,10 @@ func createCatalog(ctx context.Context, catalog internal.Catalog) (string, error
 	return catalogName, nil
 }
 
-func createSchema(ctx context.Context, catalog internal.Catalog, catalogName string) (string, error) {
-	schemaName := uuid.NewString()
+func createSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, catalogName string) (string, error) {
+	schemaName := names.New()
 
-	_, err := catalog.CreateSchema(ctx, catalogName, schemaName)
+	_, err := catalog.CreateSchema(ctx, catalogName, schemaName, nil)
 	if err != nil {
 		return "", err
 	}
@@ -46,37 +62,121 @@ func createSchema(ctx context.Context, catalog internal.Catalog, catalogName str
 	return schemaName, nil
 }
 
+// createPrincipalToken creates a principal and fetches a token with the
+// credentials that are only returned by its creation.
+func createPrincipalToken(ctx context.Context, catalog internal.Catalog, principalName string) (string, error) {
+	resp, err := catalog.CreatePrincipal(ctx, principalName, nil)
+	if err != nil {
+		return "", err
+	}
+	body, err := common.ReadBody(resp)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create principal %s: %d %s", principalName, resp.StatusCode, body)
+	}
+
+	var principal struct {
+		Credentials struct {
+			ClientID     string `json:"clientId"`
+			ClientSecret string `json:"clientSecret"`
+		} `json:"credentials"`
+	}
+	if err := json.Unmarshal(body, &principal); err != nil {
+		return "", err
+	}
+
+	return common.FetchPolarisPrincipalToken(principal.Credentials.ClientID, principal.Credentials.ClientSecret)
+}
+
+// CreatePrincipals creates principals for the worker threads to run as. With
+// roles, every principal gets its own principal role, which is granted the
+// catalog_admin role of every catalog the workers use. Every worker config is
+// scanned, so the per-thread catalogs of a setup are granted as well.
+func CreatePrincipals(ctx context.Context, catalog internal.Catalog, names *common.Names, count int, roles bool, workers []internal.WorkerConfig) ([]internal.Principal, error) {
+	catalogNames := make(map[string]bool)
+	for _, worker := range workers {
+		if catalogName, ok := worker.Params["catalogName"].(string); ok {
+			catalogNames[catalogName] = true
+		}
+	}
+
+	principals := make([]internal.Principal, 0, count)
+	for range count {
+		principalName := names.New()
+		token, err := createPrincipalToken(ctx, catalog, principalName)
+		if err != nil {
+			return nil, err
+		}
+
+		if roles {
+			principalRole := names.New()
+			if err = checkSetup(catalog.CreatePrincipalRole(ctx, principalRole)); err != nil {
+				return nil, fmt.Errorf("failed to create principal role %s: %w", principalRole, err)
+			}
+			if err = checkSetup(catalog.GrantPrincipalRole(ctx, principalName, principalRole)); err != nil {
+				return nil, fmt.Errorf("failed to grant principal role %s to %s: %w", principalRole, principalName, err)
+			}
+			for catalogName := range catalogNames {
+				if err = checkSetup(catalog.GrantCatalogRole(ctx, principalRole, catalogName, "catalog_admin")); err != nil {
+					return nil, fmt.Errorf("failed to grant catalog_admin of %s to %s: %w", catalogName, principalRole, err)
+				}
+			}
+		}
+
+		principals = append(principals, internal.Principal{Name: principalName, Token: token})
+	}
+
+	return principals, nil
+}
+
+// checkSetup turns a setup request that was not answered with a 2xx status into an error.
+func checkSetup(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	body, err := common.ReadBody(resp)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%d %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
 func CreateCatalog(threads int) ([]internal.WorkerConfig, error) {
 	return []internal.WorkerConfig{
-		{internal.CreateCatalogWorker, threads, make(map[string]interface{})},
+		{WorkerFunc: internal.CreateCatalogWorker, Threads: threads, Params: make(map[string]interface{})},
 	}, nil
 }
 
 func CreatePrincipal(threads int) ([]internal.WorkerConfig, error) {
 
 	return []internal.WorkerConfig{
-		{internal.CreatePrincipalWorker, threads, make(map[string]interface{})},
+		{WorkerFunc: internal.CreatePrincipalWorker, Threads: threads, Params: make(map[string]interface{})},
 	}, nil
 }
 
-func CreateSchema(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateSchemaWorker, threads, map[string]interface{}{"catalogName": catalogName}},
+		{WorkerFunc: internal.CreateSchemaWorker, Threads: threads, Params: map[string]interface{}{"catalogName": catalogName}},
 	}, nil
 }
 
-func CreateTable(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
@@ -84,108 +184,108 @@ func CreateTable(ctx context.Context, catalog internal.Catalog, threads int) ([]
 	err = grantPermissionCatalog(ctx, catalog, catalogName)
 
 	return []internal.WorkerConfig{
-		{internal.CreateTableWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateTableWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateView(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateViewWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateViewWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateFunction(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateFunctionWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateFunctionWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateModel(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateModelWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateModelWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateVolume(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateVolumeWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateVolumeWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateDeleteCatalog(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func CreateDeleteCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteCatalogWorker, threads, make(map[string]interface{})},
+		{WorkerFunc: internal.CreateDeleteCatalogWorker, Threads: threads, Params: make(map[string]interface{})},
 	}, nil
 }
 
-func CreateDeleteSchema(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteSchemaWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteSchemaWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName}},
 	}, nil
 }
 
-func CreateDeletePrincipal(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func CreateDeletePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	return []internal.WorkerConfig{
-		{internal.CreateDeletePrincipalWorker, threads, make(map[string]interface{})},
+		{WorkerFunc: internal.CreateDeletePrincipalWorker, Threads: threads, Params: make(map[string]interface{})},
 	}, nil
 }
 
-func CreateDeleteTable(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
@@ -193,245 +293,932 @@ func CreateDeleteTable(ctx context.Context, catalog internal.Catalog, threads in
 	err = grantPermissionCatalog(ctx, catalog, catalogName)
 
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteTableWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteTableWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateDeleteView(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteViewWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteViewWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateDeleteFunction(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateFunctionWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateFunctionWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateDeleteModel(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteModelWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteModelWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateDeleteVolume(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.CreateDeleteVolumeWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteVolumeWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func UpdateCatalog(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func UpdateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.UpdateCatalogWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateCatalogWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName}},
 	}, nil
 }
 
-func UpdatePrincipal(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	principalName := uuid.NewString()
+func UpdatePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	principalName := names.New()
 
-	_, err := catalog.CreatePrincipal(ctx, principalName)
+	_, err := catalog.CreatePrincipal(ctx, principalName, nil)
 	if err != nil {
 		return nil, err
 	}
 	return []internal.WorkerConfig{
-		{internal.UpdatePrincipalWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdatePrincipalWorker, Threads: threads, Params: map[string]interface{}{
 			"principalName": principalName}},
 	}, nil
 }
 
-func UpdateSchema(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func ConflictUpdateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.ConflictUpdateCatalogWorker, Threads: threads, Params: map[string]interface{}{
+			"catalogName": catalogName}},
+	}, nil
+}
+
+func ConflictUpdatePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	principalName := names.New()
+
+	_, err := catalog.CreatePrincipal(ctx, principalName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.ConflictUpdatePrincipalWorker, Threads: threads, Params: map[string]interface{}{
+			"principalName": principalName}},
+	}, nil
+}
+
+// propertyWorkers gives each thread its own property key on the shared entity,
+// and adds an audit that checks every key against its acknowledged writes.
+func propertyWorkers(threads int, workerFunc internal.WorkerFunc, auditFunc internal.WorkerFunc, params map[string]interface{}) []internal.WorkerConfig {
+	acknowledged := make(map[string]*atomic.Int64, threads)
+	workers := make([]internal.WorkerConfig, 0, threads+1)
+
+	for thread := range threads {
+		propertyKey := fmt.Sprintf("thread-%d", thread)
+		acknowledged[propertyKey] = &atomic.Int64{}
+
+		workerParams := map[string]interface{}{
+			"propertyKey":  propertyKey,
+			"acknowledged": acknowledged,
+		}
+		for k, v := range params {
+			workerParams[k] = v
+		}
+		workers = append(workers, internal.WorkerConfig{WorkerFunc: workerFunc, Threads: 1, Params: workerParams})
+	}
+
+	auditParams := map[string]interface{}{"acknowledged": acknowledged}
+	for k, v := range params {
+		auditParams[k] = v
+	}
+	workers = append(workers, internal.WorkerConfig{WorkerFunc: auditFunc, Threads: 1, Params: auditParams, Audit: true})
+
+	return workers
+}
+
+func PropertyUpdateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return propertyWorkers(threads, internal.PropertyUpdateCatalogWorker, internal.PropertyAuditCatalogWorker, map[string]interface{}{
+		"catalogName": catalogName,
+	}), nil
+}
+
+func PropertyUpdateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	return propertyWorkers(threads, internal.PropertyUpdateSchemaWorker, internal.PropertyAuditSchemaWorker, map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+	}), nil
+}
+
+func PropertyUpdateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	tableName := names.New()
+	_, err = catalog.CreateTable(ctx, catalogName, schemaName, tableName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return propertyWorkers(threads, internal.PropertyUpdateTableWorker, internal.PropertyAuditTableWorker, map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"tableName":   tableName,
+	}), nil
+}
+
+func RaceCreateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.RaceCreateCatalogWorker, Threads: threads, Params: map[string]interface{}{
+			"race": internal.NewRace(threads), "raceName": names.New()}},
+	}, nil
+}
+
+func RaceCreatePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.RaceCreatePrincipalWorker, Threads: threads, Params: map[string]interface{}{
+			"race": internal.NewRace(threads), "raceName": names.New()}},
+	}, nil
+}
+
+func RaceCreateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.RaceCreateSchemaWorker, Threads: threads, Params: map[string]interface{}{
+			"catalogName": catalogName, "race": internal.NewRace(threads), "raceName": names.New()}},
+	}, nil
+}
+
+func RaceCreateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.RaceCreateTableWorker, Threads: threads, Params: map[string]interface{}{
+			"catalogName": catalogName, "schemaName": schemaName, "race": internal.NewRace(threads), "raceName": names.New()}},
+	}, nil
+}
+
+// parentChildWorkers creates the children with all but one thread, while the
+// remaining thread deletes and re-creates their parent.
+func parentChildWorkers(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, workerFunc internal.WorkerFunc, auditFunc internal.WorkerFunc) ([]internal.WorkerConfig, error) {
+	if threads < 2 {
+		return nil, fmt.Errorf("the parent/child benchmark needs at least 2 threads, one to churn the parent and one to create children")
+	}
+
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	params := map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"tracker":     internal.NewParentTracker(),
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.ChurnParentWorker, Threads: 1, Params: params},
+		{WorkerFunc: workerFunc, Threads: threads - 1, Params: params},
+		{WorkerFunc: auditFunc, Threads: 1, Params: params, Audit: true},
+	}, nil
+}
+
+func ParentChildTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return parentChildWorkers(ctx, catalog, names, threads, internal.ParentChildTableWorker, internal.ParentChildAuditTableWorker)
+}
+
+func ParentChildView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return parentChildWorkers(ctx, catalog, names, threads, internal.ParentChildViewWorker, internal.ParentChildAuditViewWorker)
+}
+
+func ParentChildFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return parentChildWorkers(ctx, catalog, names, threads, internal.ParentChildFunctionWorker, internal.ParentChildAuditFunctionWorker)
+}
+
+func ParentChildVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return parentChildWorkers(ctx, catalog, names, threads, internal.ParentChildVolumeWorker, internal.ParentChildAuditVolumeWorker)
+}
+
+// recreateWorkers gives each thread its own fixed entity name to drop and re-create.
+func recreateWorkers(names *common.Names, threads int, workerFunc internal.WorkerFunc, params map[string]interface{}) []internal.WorkerConfig {
+	workers := make([]internal.WorkerConfig, threads)
+	for thread := range threads {
+		workerParams := map[string]interface{}{"entityName": names.New()}
+		for k, v := range params {
+			workerParams[k] = v
+		}
+		workers[thread] = internal.WorkerConfig{WorkerFunc: workerFunc, Threads: 1, Params: workerParams}
+	}
+	return workers
+}
+
+func RecreateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateWorkers(names, threads, internal.RecreateCatalogWorker, nil), nil
+}
+
+func RecreatePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateWorkers(names, threads, internal.RecreatePrincipalWorker, nil), nil
+}
+
+func RecreateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return recreateWorkers(names, threads, internal.RecreateSchemaWorker, map[string]interface{}{
+		"catalogName": catalogName,
+	}), nil
+}
+
+// recreateChildWorkers sets up the catalog and schema for entities that live in a schema.
+func recreateChildWorkers(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, workerFunc internal.WorkerFunc) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	return recreateWorkers(names, threads, workerFunc, map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+	}), nil
+}
+
+func RecreateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateChildWorkers(ctx, catalog, names, threads, internal.RecreateTableWorker)
+}
+
+func RecreateView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateChildWorkers(ctx, catalog, names, threads, internal.RecreateViewWorker)
+}
+
+func RecreateFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateChildWorkers(ctx, catalog, names, threads, internal.RecreateFunctionWorker)
+}
+
+func RecreateModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateChildWorkers(ctx, catalog, names, threads, internal.RecreateModelWorker)
+}
+
+func RecreateVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	return recreateChildWorkers(ctx, catalog, names, threads, internal.RecreateVolumeWorker)
+}
+
+// populate creates count entities from threads goroutines and returns the
+// names of the entities that were created, in the order they were drawn.
+func populate(ctx context.Context, names *common.Names, threads int, count int, create func(name string) (*http.Response, error)) ([]string, error) {
+	drawn := make([]string, count)
+	for i := range drawn {
+		drawn[i] = names.New()
+	}
+
+	// Every goroutine writes the outcome of its own index, so the names keep the order they were drawn in
+	created := make([]bool, count)
+	err := createConcurrently(ctx, threads, count, func(i int) error {
+		resp, err := create(drawn[i])
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		created[i] = resp.StatusCode >= 200 && resp.StatusCode <= 299
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	populated := make([]string, 0, count)
+	for i, name := range drawn {
+		if created[i] {
+			populated = append(populated, name)
+		}
+	}
+	return populated, nil
+}
+
+// paginationWorkers lists the pre-populated entities with one thread, while the
+// other threads create and delete entities next to them.
+func paginationWorkers(threads int, listFunc internal.WorkerFunc, churnFunc internal.WorkerFunc, populated []string, pageSize int, params map[string]interface{}) []internal.WorkerConfig {
+	listParams := map[string]interface{}{"populated": populated, "pageSize": pageSize}
+	for k, v := range params {
+		listParams[k] = v
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: listFunc, Threads: 1, Params: listParams},
+		{WorkerFunc: churnFunc, Threads: threads - 1, Params: params},
+	}
+}
+
+func PaginationCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	populated, err := populate(ctx, names, PopulateThreads, count, func(name string) (*http.Response, error) {
+		return catalog.CreateCatalog(ctx, name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginationWorkers(threads, internal.PaginationListCatalogsWorker, internal.CreateDeleteCatalogWorker, populated, pageSize, map[string]interface{}{}), nil
+}
+
+func PaginationSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	populated, err := populate(ctx, names, PopulateThreads, count, func(name string) (*http.Response, error) {
+		return catalog.CreateSchema(ctx, catalogName, name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginationWorkers(threads, internal.PaginationListSchemasWorker, internal.CreateDeleteSchemaWorker, populated, pageSize, map[string]interface{}{
+		"catalogName": catalogName,
+	}), nil
+}
+
+// paginationChildWorkers pre-populates a schema with entities that live in a schema.
+func paginationChildWorkers(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int, listFunc internal.WorkerFunc, churnFunc internal.WorkerFunc, create func(catalogName string, schemaName string, name string) (*http.Response, error)) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	populated, err := populate(ctx, names, PopulateThreads, count, func(name string) (*http.Response, error) {
+		return create(catalogName, schemaName, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paginationWorkers(threads, listFunc, churnFunc, populated, pageSize, map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+	}), nil
+}
+
+func PaginationTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	return paginationChildWorkers(ctx, catalog, names, threads, count, pageSize, internal.PaginationListTablesWorker, internal.CreateDeleteTableWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateTable(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func PaginationView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	return paginationChildWorkers(ctx, catalog, names, threads, count, pageSize, internal.PaginationListViewsWorker, internal.CreateDeleteViewWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateView(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func PaginationFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	return paginationChildWorkers(ctx, catalog, names, threads, count, pageSize, internal.PaginationListFunctionsWorker, internal.CreateDeleteFunctionWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateFunction(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func PaginationModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	return paginationChildWorkers(ctx, catalog, names, threads, count, pageSize, internal.PaginationListModelsWorker, internal.CreateDeleteModelWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateModel(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func PaginationVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, count int, pageSize int) ([]internal.WorkerConfig, error) {
+	return paginationChildWorkers(ctx, catalog, names, threads, count, pageSize, internal.PaginationListVolumesWorker, internal.CreateDeleteVolumeWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateVolume(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func CommitTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	tableName := names.New()
+	_, err = catalog.CreateTable(ctx, catalogName, schemaName, tableName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"tableName":   tableName,
+		"committed":   &sync.Map{},
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.CommitTableWorker, Threads: threads, Params: params},
+		{WorkerFunc: internal.CommitAuditTableWorker, Threads: 1, Params: params, Audit: true},
+	}, nil
+}
+
+// ViewVersion adds view versions to one view with half of the threads, while
+// the other half reads the view.
+func ViewVersion(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	viewName := names.New()
+	_, err = catalog.CreateView(ctx, catalogName, schemaName, viewName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"viewName":    viewName,
+	}
+
+	writers := max(1, threads/2)
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.ViewVersionWriterWorker, Threads: writers, Params: params},
+		{WorkerFunc: internal.ViewVersionReaderWorker, Threads: threads - writers, Params: params},
+	}, nil
+}
+
+// ModelVersion creates and finalizes versions of one model across all threads,
+// followed by an audit of the listed versions.
+func ModelVersion(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	modelName := names.New()
+	_, err = catalog.CreateModel(ctx, catalogName, schemaName, modelName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"modelName":   modelName,
+		"versions":    &sync.Map{},
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.ModelVersionWorker, Threads: threads, Params: params},
+		{WorkerFunc: internal.ModelVersionAuditWorker, Threads: 1, Params: params, Audit: true},
+	}, nil
+}
+
+// PermissionChurnTable revokes and grants access to a table with one thread,
+// while the other threads load the table as a principal that depends on it.
+func PermissionChurnTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	const catalogRole = "benchmark_reader"
+	const privilege = "TABLE_READ_DATA"
+
+	// The principal role is created first, so a catalog without roles is rejected before anything else is created
+	principalRole := names.New()
+	err := checkSetup(catalog.CreatePrincipalRole(ctx, principalRole))
+	if errors.Is(err, common.ErrNotImplemented) {
+		return nil, fmt.Errorf("the permission churn benchmark needs principal and catalog roles, which this catalog does not support: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create principal role %s: %w", principalRole, err)
+	}
+
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = grantPermissionCatalog(ctx, catalog, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	tableName := names.New()
+	if err = checkSetup(catalog.CreateTable(ctx, catalogName, schemaName, tableName, nil)); err != nil {
+		return nil, fmt.Errorf("failed to create table %s: %w", tableName, err)
+	}
+
+	// The principal reads through principal role -> catalog role -> privilege, all of which are churned.
+	// The tracker starts with all of them held, so every grant has to succeed
+	if err = checkSetup(catalog.CreateCatalogRole(ctx, catalogName, catalogRole)); err != nil {
+		return nil, fmt.Errorf("failed to create catalog role %s: %w", catalogRole, err)
+	}
+	err = checkSetup(catalog.GrantPermissionCatalog(ctx, catalogName, map[string]interface{}{"privilege": privilege, "catalogRole": catalogRole}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant %s to %s: %w", privilege, catalogRole, err)
+	}
+	if err = checkSetup(catalog.GrantCatalogRole(ctx, principalRole, catalogName, catalogRole)); err != nil {
+		return nil, fmt.Errorf("failed to grant catalog role %s to %s: %w", catalogRole, principalRole, err)
+	}
+
+	principalName := names.New()
+	token, err := createPrincipalToken(ctx, catalog, principalName)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkSetup(catalog.GrantPrincipalRole(ctx, principalName, principalRole)); err != nil {
+		return nil, fmt.Errorf("failed to grant principal role %s to %s: %w", principalRole, principalName, err)
+	}
+
+	params := map[string]interface{}{
+		"catalogName":   catalogName,
+		"schemaName":    schemaName,
+		"tableName":     tableName,
+		"catalogRole":   catalogRole,
+		"principalRole": principalRole,
+		"principalName": principalName,
+		"privilege":     privilege,
+		"token":         token,
+		"tracker":       internal.NewPermissionTracker("privilege", "catalog_role", "principal_role"),
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.PermissionChurnWorker, Threads: 1, Params: params},
+		{WorkerFunc: internal.PermissionAccessWorker, Threads: threads - 1, Params: params},
+		{WorkerFunc: internal.PermissionAuditWorker, Threads: 1, Params: params, Audit: true},
+	}, nil
+}
+
+// NamespaceTree builds and tears down a namespace tree of the given depth and
+// fan-out per thread, all below one shared root namespace.
+func NamespaceTree(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, depth int, fanOut int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	rootNamespace, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists the still empty root namespace, to reject a catalog without nested namespaces before the threads start
+	responses, err := catalog.ListSchemas(ctx, catalogName, map[string]interface{}{"parentNamespace": rootNamespace})
+	for _, resp := range responses {
+		resp.Body.Close()
+	}
+	if errors.Is(err, common.ErrNotImplemented) {
+		return nil, fmt.Errorf("the namespace tree benchmark needs nested namespaces, which this catalog does not support: %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: internal.NamespaceTreeWorker, Threads: threads, Params: map[string]interface{}{
+			"catalogName": catalogName, "rootNamespace": rootNamespace, "depth": depth, "fanOut": fanOut}},
+	}, nil
+}
+
+// manifestReadWorkers samples the entities of the populated manifest.
+func manifestReadWorkers(threads int, workerFunc internal.WorkerFunc, manifest *internal.Manifest, entity common.EntityType) ([]internal.WorkerConfig, error) {
+	if len(manifest.Entries(entity)) == 0 {
+		return nil, fmt.Errorf("the manifest contains no %s", entity)
+	}
+
+	return []internal.WorkerConfig{
+		{WorkerFunc: workerFunc, Threads: threads, Params: map[string]interface{}{"manifest": manifest}},
+	}, nil
+}
+
+func ManifestReadCatalog(ctx context.Context, catalog internal.Catalog, threads int, manifest *internal.Manifest) ([]internal.WorkerConfig, error) {
+	return manifestReadWorkers(threads, internal.ManifestReadCatalogWorker, manifest, common.CatalogEntity)
+}
+
+func ManifestReadSchema(ctx context.Context, catalog internal.Catalog, threads int, manifest *internal.Manifest) ([]internal.WorkerConfig, error) {
+	return manifestReadWorkers(threads, internal.ManifestReadSchemaWorker, manifest, common.SchemaEntity)
+}
+
+func ManifestReadTable(ctx context.Context, catalog internal.Catalog, threads int, manifest *internal.Manifest) ([]internal.WorkerConfig, error) {
+	return manifestReadWorkers(threads, internal.ManifestReadTableWorker, manifest, common.TableEntity)
+}
+
+// TransactionTable commits to a fixed set of tables with half of the threads,
+// while the other half reads the tables.
+func TransactionTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	const transactionTables = 3
+
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = grantPermissionCatalog(ctx, catalog, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	tableNames := make([]string, transactionTables)
+	for i := range tableNames {
+		tableNames[i] = names.New()
+		_, err = catalog.CreateTable(ctx, catalogName, schemaName, tableNames[i], nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writers := max(1, threads/2)
+	writerKeys := make([]string, writers)
+	stats := &internal.TransactionStats{}
+	workers := make([]internal.WorkerConfig, 0, writers+2)
+
+	for writer := range writers {
+		writerKeys[writer] = fmt.Sprintf("writer-%d", writer)
+		workers = append(workers, internal.WorkerConfig{WorkerFunc: internal.TransactionWriterWorker, Threads: 1, Params: map[string]interface{}{
+			"catalogName": catalogName, "schemaName": schemaName, "tableNames": tableNames, "writerKey": writerKeys[writer], "stats": stats}})
+	}
+
+	params := map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+		"tableNames":  tableNames,
+		"writerKeys":  writerKeys,
+		"stats":       stats,
+	}
+	workers = append(workers,
+		internal.WorkerConfig{WorkerFunc: internal.TransactionReaderWorker, Threads: threads - writers, Params: params},
+		internal.WorkerConfig{WorkerFunc: internal.TransactionAuditWorker, Threads: 1, Params: params, Audit: true},
+	)
+
+	return workers, nil
+}
+
+func UpdateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.UpdateSchemaWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateSchemaWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func UpdateTable(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func UpdateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
-	tableName := uuid.NewString()
+	tableName := names.New()
 
-	_, err = catalog.CreatePrincipal(ctx, tableName)
+	_, err = catalog.CreatePrincipal(ctx, tableName, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	err = grantPermissionCatalog(ctx, catalog, catalogName)
 	return []internal.WorkerConfig{
-		{internal.UpdateTableWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateTableWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName, "tableName": tableName}},
 	}, nil
 
 }
 
-func UpdateView(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func UpdateView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
-	viewName := uuid.NewString()
-	_, err = catalog.CreateView(ctx, catalogName, schemaName, viewName)
+	viewName := names.New()
+	_, err = catalog.CreateView(ctx, catalogName, schemaName, viewName, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.UpdateViewWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateViewWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName, "viewName": viewName}},
 	}, nil
 }
 
-func UpdateModel(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func UpdateModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
-	modelName := uuid.NewString()
+	modelName := names.New()
 
-	_, err = catalog.CreateModel(ctx, catalogName, schemaName, modelName)
+	_, err = catalog.CreateModel(ctx, catalogName, schemaName, modelName, nil)
 	if err != nil {
 		return nil, err
 	}
 	return []internal.WorkerConfig{
-		{internal.UpdateModelWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateModelWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName, "modelName": modelName}},
 	}, nil
 }
-func UpdateVolume(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func UpdateVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
-	volumeName := uuid.NewString()
-	_, err = catalog.CreateVolume(ctx, catalogName, schemaName, volumeName)
+	volumeName := names.New()
+	_, err = catalog.CreateVolume(ctx, catalogName, schemaName, volumeName, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.UpdateVolumeWorker, threads, map[string]interface{}{
+		{WorkerFunc: internal.UpdateVolumeWorker, Threads: threads, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName, "volumeName": volumeName}},
 	}, nil
 }
 
-func CreateDeleteListSchema(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.ListSchemasWorker, 1, map[string]interface{}{"catalogName": catalogName}},
-		{internal.CreateDeleteSchemaWorker, threads - 1, map[string]interface{}{"catalogName": catalogName}},
+		{WorkerFunc: internal.ListSchemasWorker, Threads: 1, Params: map[string]interface{}{"catalogName": catalogName}},
+		{WorkerFunc: internal.CreateDeleteSchemaWorker, Threads: threads - 1, Params: map[string]interface{}{"catalogName": catalogName}},
 	}, nil
 }
 
-func CreateDeleteListCatalog(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func CreateDeleteListCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	return []internal.WorkerConfig{
-		{internal.ListCatalogsWorker, 1, make(map[string]interface{})},
-		{internal.CreateDeleteCatalogWorker, threads - 1, make(map[string]interface{})},
+		{WorkerFunc: internal.ListCatalogsWorker, Threads: 1, Params: make(map[string]interface{})},
+		{WorkerFunc: internal.CreateDeleteCatalogWorker, Threads: threads - 1, Params: make(map[string]interface{})},
 	}, nil
 }
 
-func CreateDeleteListPrincipal(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func CreateDeleteListPrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	return []internal.WorkerConfig{
-		{internal.ListPrincipalsWorker, 1, make(map[string]interface{})},
-		{internal.CreateDeletePrincipalWorker, threads - 1, make(map[string]interface{})},
+		{WorkerFunc: internal.ListPrincipalsWorker, Threads: 1, Params: make(map[string]interface{})},
+		{WorkerFunc: internal.CreateDeletePrincipalWorker, Threads: threads - 1, Params: make(map[string]interface{})},
 	}, nil
 }
 
-func CreateDeleteListTable(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
@@ -442,97 +1229,97 @@ func CreateDeleteListTable(ctx context.Context, catalog internal.Catalog, thread
 	}
 
 	return []internal.WorkerConfig{
-		{internal.ListTablesWorker, 1, map[string]interface{}{
+		{WorkerFunc: internal.ListTablesWorker, Threads: 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
-		{internal.CreateDeleteTableWorker, threads - 1, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteTableWorker, Threads: threads - 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func CreateDeleteListView(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.ListViewsWorker, 1, map[string]interface{}{
+		{WorkerFunc: internal.ListViewsWorker, Threads: 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
-		{internal.CreateDeleteViewWorker, threads - 1, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteViewWorker, Threads: threads - 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateDeleteListFunction(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListFunction(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 	return []internal.WorkerConfig{
-		{internal.ListFunctionsWorker, 1, map[string]interface{}{
+		{WorkerFunc: internal.ListFunctionsWorker, Threads: 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
-		{internal.CreateDeleteFunctionWorker, threads - 1, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteFunctionWorker, Threads: threads - 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateDeleteListModel(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.ListModelsWorker, 1, map[string]interface{}{
+		{WorkerFunc: internal.ListModelsWorker, Threads: 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
-		{internal.CreateDeleteModelWorker, threads - 1, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteModelWorker, Threads: threads - 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
-func CreateDeleteListVolume(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
-	catalogName, err := createCatalog(ctx, catalog)
+func CreateDeleteListVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	return []internal.WorkerConfig{
-		{internal.ListVolumesWorker, 1, map[string]interface{}{
+		{WorkerFunc: internal.ListVolumesWorker, Threads: 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
-		{internal.CreateDeleteVolumeWorker, threads - 1, map[string]interface{}{
+		{WorkerFunc: internal.CreateDeleteVolumeWorker, Threads: threads - 1, Params: map[string]interface{}{
 			"catalogName": catalogName, "schemaName": schemaName}},
 	}, nil
 }
 
-func UpdateGetCatalog(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
 	for thread := range threads {
-		catalogName, err := createCatalog(ctx, catalog)
+		catalogName, err := createCatalog(ctx, catalog, names)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetCatalogWorker,
-			1,
-			map[string]interface{}{
+			WorkerFunc: internal.UpdateGetCatalogWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 			},
 		}
@@ -540,19 +1327,19 @@ func UpdateGetCatalog(ctx context.Context, catalog internal.Catalog, threads int
 	return workers, nil
 }
 
-func UpdateGetPrincipal(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetPrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
 
 	for thread := range threads {
-		principalName := uuid.NewString()
-		_, err := catalog.CreatePrincipal(ctx, principalName)
+		principalName := names.New()
+		_, err := catalog.CreatePrincipal(ctx, principalName, nil)
 		if err != nil {
 			return nil, err
 		}
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetPrincipalWorker,
-			1,
-			map[string]interface{}{
+			WorkerFunc: internal.UpdateGetPrincipalWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"principalName": principalName,
 			},
 		}
@@ -561,23 +1348,23 @@ func UpdateGetPrincipal(ctx context.Context, catalog internal.Catalog, threads i
 
 }
 
-func UpdateGetSchema(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
 
-	catalogName, err := createCatalog(ctx, catalog)
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 	for thread := range threads {
-		schemaName, err := createSchema(ctx, catalog, catalogName)
+		schemaName, err := createSchema(ctx, catalog, names, catalogName)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetSchemaWorker,
-			1,
-			map[string]interface{}{
+			WorkerFunc: internal.UpdateGetSchemaWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 				"schemaName":  schemaName,
 			},
@@ -588,30 +1375,30 @@ func UpdateGetSchema(ctx context.Context, catalog internal.Catalog, threads int)
 	return workers, nil
 }
 
-func UpdateGetTable(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
 
-	catalogName, err := createCatalog(ctx, catalog)
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	for thread := range threads {
-		tableName := uuid.NewString()
-		_, err := catalog.CreateTable(ctx, catalogName, schemaName, tableName)
+		tableName := names.New()
+		_, err := catalog.CreateTable(ctx, catalogName, schemaName, tableName, nil)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetTableWorker,
-			1,
-			map[string]interface{}{
+			WorkerFunc: internal.UpdateGetTableWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 				"schemaName":  schemaName,
 				"tableName":   tableName,
@@ -627,28 +1414,30 @@ func UpdateGetTable(ctx context.Context, catalog internal.Catalog, threads int)
 	return workers, nil
 }
 
-func UpdateGetView(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
-	catalogName, err := createCatalog(ctx, catalog)
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	for thread := range threads {
 
-		viewName := uuid.NewString()
-		_, err = catalog.CreateView(ctx, catalogName, schemaName, viewName)
+		viewName := names.New()
+		_, err = catalog.CreateView(ctx, catalogName, schemaName, viewName, nil)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetViewWorker, 1, map[string]interface{}{
+			WorkerFunc: internal.UpdateGetViewWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 				"schemaName":  schemaName,
 				"viewName":    viewName,
@@ -659,26 +1448,28 @@ func UpdateGetView(ctx context.Context, catalog internal.Catalog, threads int) (
 	return workers, nil
 }
 
-func UpdateGetModel(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
-	catalogName, err := createCatalog(ctx, catalog)
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 	for thread := range threads {
-		modelName := uuid.NewString()
-		_, err = catalog.CreateModel(ctx, catalogName, schemaName, modelName)
+		modelName := names.New()
+		_, err = catalog.CreateModel(ctx, catalogName, schemaName, modelName, nil)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetModelWorker, 1, map[string]interface{}{
+			WorkerFunc: internal.UpdateGetModelWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 				"schemaName":  schemaName,
 				"modelName":   modelName,
@@ -688,27 +1479,29 @@ func UpdateGetModel(ctx context.Context, catalog internal.Catalog, threads int)
 	}
 	return workers, nil
 }
-func UpdateGetVolume(ctx context.Context, catalog internal.Catalog, threads int) ([]internal.WorkerConfig, error) {
+func UpdateGetVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int) ([]internal.WorkerConfig, error) {
 	workers := make([]internal.WorkerConfig, threads)
-	catalogName, err := createCatalog(ctx, catalog)
+	catalogName, err := createCatalog(ctx, catalog, names)
 	if err != nil {
 		return nil, err
 	}
 
-	schemaName, err := createSchema(ctx, catalog, catalogName)
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
 	if err != nil {
 		return nil, err
 	}
 
 	for thread := range threads {
-		volumeName := uuid.NewString()
-		_, err = catalog.CreateVolume(ctx, catalogName, schemaName, volumeName)
+		volumeName := names.New()
+		_, err = catalog.CreateVolume(ctx, catalogName, schemaName, volumeName, nil)
 		if err != nil {
 			return nil, err
 		}
 
 		workers[thread] = internal.WorkerConfig{
-			internal.UpdateGetVolumeWorker, 1, map[string]interface{}{
+			WorkerFunc: internal.UpdateGetVolumeWorker,
+			Threads:    1,
+			Params: map[string]interface{}{
 				"catalogName": catalogName,
 				"schemaName":  schemaName,
 				"volumeName":  volumeName,
@@ -719,3 +1512,110 @@ func UpdateGetVolume(ctx context.Context, catalog internal.Catalog, threads int)
 
 	return workers, nil
 }
+
+// skewedWorkers updates keys of the populated pool, which every thread chooses
+// following the same distribution.
+func skewedWorkers(threads int, param string, workerFunc internal.WorkerFunc, pool []string, distribution common.Distribution, params map[string]interface{}) ([]internal.WorkerConfig, error) {
+	if len(pool) == 0 {
+		return nil, fmt.Errorf("no entities were populated")
+	}
+
+	params["pool"] = pool
+	params["keyParam"] = param
+	params["distribution"] = distribution
+	params["versions"] = internal.NewKeyVersions(pool)
+	return []internal.WorkerConfig{
+		{WorkerFunc: workerFunc, Threads: threads, Params: params},
+	}, nil
+}
+
+func SkewedUpdateCatalog(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	pool, err := populate(ctx, names, PopulateThreads, keys, func(name string) (*http.Response, error) {
+		return catalog.CreateCatalog(ctx, name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skewedWorkers(threads, "catalogName", internal.UpdateCatalogWorker, pool, distribution, map[string]interface{}{})
+}
+
+func SkewedUpdatePrincipal(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	pool, err := populate(ctx, names, PopulateThreads, keys, func(name string) (*http.Response, error) {
+		return catalog.CreatePrincipal(ctx, name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skewedWorkers(threads, "principalName", internal.UpdatePrincipalWorker, pool, distribution, map[string]interface{}{})
+}
+
+func SkewedUpdateSchema(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := populate(ctx, names, PopulateThreads, keys, func(name string) (*http.Response, error) {
+		return catalog.CreateSchema(ctx, catalogName, name, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skewedWorkers(threads, "schemaName", internal.UpdateSchemaWorker, pool, distribution, map[string]interface{}{
+		"catalogName": catalogName,
+	})
+}
+
+// skewedChildWorkers populates a schema with the pool of entities that live in a schema.
+func skewedChildWorkers(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution, param string, workerFunc internal.WorkerFunc, create func(catalogName string, schemaName string, name string) (*http.Response, error)) ([]internal.WorkerConfig, error) {
+	catalogName, err := createCatalog(ctx, catalog, names)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaName, err := createSchema(ctx, catalog, names, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	grantBestEffort(ctx, catalog, catalogName)
+
+	pool, err := populate(ctx, names, PopulateThreads, keys, func(name string) (*http.Response, error) {
+		return create(catalogName, schemaName, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return skewedWorkers(threads, param, workerFunc, pool, distribution, map[string]interface{}{
+		"catalogName": catalogName,
+		"schemaName":  schemaName,
+	})
+}
+
+func SkewedUpdateTable(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	return skewedChildWorkers(ctx, catalog, names, threads, keys, distribution, "tableName", internal.UpdateTableWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateTable(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func SkewedUpdateView(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	return skewedChildWorkers(ctx, catalog, names, threads, keys, distribution, "viewName", internal.UpdateViewWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateView(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func SkewedUpdateModel(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	return skewedChildWorkers(ctx, catalog, names, threads, keys, distribution, "modelName", internal.UpdateModelWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateModel(ctx, catalogName, schemaName, name, nil)
+	})
+}
+
+func SkewedUpdateVolume(ctx context.Context, catalog internal.Catalog, names *common.Names, threads int, keys int, distribution common.Distribution) ([]internal.WorkerConfig, error) {
+	return skewedChildWorkers(ctx, catalog, names, threads, keys, distribution, "volumeName", internal.UpdateVolumeWorker, func(catalogName string, schemaName string, name string) (*http.Response, error) {
+		return catalog.CreateVolume(ctx, catalogName, schemaName, name, nil)
+	})
+}
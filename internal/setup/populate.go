@@ -0,0 +1,237 @@
+package setup
+
+import (
+	"benchmark/internal"
+	"benchmark/internal/common"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PopulateThreads is the number of threads that populate a catalog unless
+// configured otherwise.
+const PopulateThreads = 50
+
+// populator creates the entities of one level of the tree concurrently and
+// appends every entity that exists afterwards to the manifest file.
+type populator struct {
+	threads int
+	done    map[internal.ManifestEntry]bool // Entities already in the manifest when the run started
+	total   int
+
+	mu   sync.Mutex
+	file *os.File
+
+	created atomic.Int64
+	failed  atomic.Int64
+}
+
+// Populate creates the catalogs, schemas and tables of the tree level by level
+// and records them in the manifest. Entities already in the manifest are
+// skipped, so an interrupted run resumes where it stopped.
+func Populate(ctx context.Context, catalog internal.Catalog, tree common.Tree, threads int, manifestPath string) (*internal.Manifest, error) {
+	manifest, err := internal.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &populator{
+		threads: threads,
+		done:    make(map[internal.ManifestEntry]bool),
+		total:   tree.Size(),
+	}
+	for _, entries := range [][]internal.ManifestEntry{manifest.Catalogs, manifest.Schemas, manifest.Tables} {
+		for _, entry := range entries {
+			p.done[entry] = true
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", manifestPath, err)
+	}
+	p.file, err = os.OpenFile(manifestPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", manifestPath, err)
+	}
+	defer p.file.Close()
+
+	stop := p.reportProgress(5 * time.Second)
+	defer stop()
+
+	// Names are derived from the manifest name, so a resumed run creates the same entities
+	prefix := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.TrimSuffix(filepath.Base(manifestPath), filepath.Ext(manifestPath)))
+
+	catalogs := make([]internal.ManifestEntry, 0, tree.Catalogs)
+	for i := range tree.Catalogs {
+		catalogs = append(catalogs, internal.ManifestEntry{Catalog: fmt.Sprintf("%s_c%d", prefix, i)})
+	}
+	catalogs, err = p.level(ctx, catalogs, func(entry internal.ManifestEntry) (*http.Response, error) {
+		return catalog.CreateCatalog(ctx, entry.Catalog, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]internal.ManifestEntry, 0, len(catalogs)*tree.Schemas)
+	for _, parent := range catalogs {
+		grantBestEffort(ctx, catalog, parent.Catalog)
+		for i := range tree.Schemas {
+			schemas = append(schemas, internal.ManifestEntry{Catalog: parent.Catalog, Schema: fmt.Sprintf("s%d", i)})
+		}
+	}
+	schemas, err = p.level(ctx, schemas, func(entry internal.ManifestEntry) (*http.Response, error) {
+		return catalog.CreateSchema(ctx, entry.Catalog, entry.Schema, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]internal.ManifestEntry, 0, len(schemas)*tree.Tables)
+	for _, parent := range schemas {
+		for i := range tree.Tables {
+			tables = append(tables, internal.ManifestEntry{Catalog: parent.Catalog, Schema: parent.Schema, Table: fmt.Sprintf("t%d", i)})
+		}
+	}
+	_, err = p.level(ctx, tables, func(entry internal.ManifestEntry) (*http.Response, error) {
+		return catalog.CreateTable(ctx, entry.Catalog, entry.Schema, entry.Table, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Populated %d entities, %d failed, manifest %s", len(p.done)+int(p.created.Load()), p.failed.Load(), manifestPath)
+	return internal.LoadManifest(manifestPath)
+}
+
+// level creates the entities that are not in the manifest yet and returns all
+// entities of the level that exist, so their children can be created. A
+// conflict counts as created, as an interrupted run may have created the
+// entity without recording it.
+func (p *populator) level(ctx context.Context, entries []internal.ManifestEntry, create func(entry internal.ManifestEntry) (*http.Response, error)) ([]internal.ManifestEntry, error) {
+	existing := make([]internal.ManifestEntry, 0, len(entries))
+	pending := make([]internal.ManifestEntry, 0, len(entries))
+	for _, entry := range entries {
+		if p.done[entry] {
+			existing = append(existing, entry)
+		} else {
+			pending = append(pending, entry)
+		}
+	}
+
+	err := createConcurrently(ctx, p.threads, len(pending), func(i int) error {
+		resp, err := create(pending[i])
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if (resp.StatusCode < 200 || resp.StatusCode > 299) && resp.StatusCode != http.StatusConflict {
+			p.failed.Add(1)
+			return nil
+		}
+
+		if err := p.record(pending[i]); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		existing = append(existing, pending[i])
+		p.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// createConcurrently calls create for every index below count from threads
+// goroutines. It stops handing out indexes at the first error or when the
+// context is done, and returns that error.
+func createConcurrently(ctx context.Context, threads int, count int, create func(i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes := make(chan int)
+	errs := make(chan error, threads)
+
+	var wg sync.WaitGroup
+	for range threads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := create(i); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range count {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+func (p *populator) record(entry internal.ManifestEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	p.created.Add(1)
+	return nil
+}
+
+// reportProgress logs the progress periodically until the returned function is called.
+func (p *populator) reportProgress(interval time.Duration) func() {
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				created := p.created.Load()
+				log.Printf("Populated %d of %d entities, %d failed (%.0f/s)",
+					len(p.done)+int(created), p.total, p.failed.Load(), float64(created)/time.Since(start).Seconds())
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,14 @@
+package internal
+
+import (
+	"sync/atomic"
+)
+
+// TransactionStats counts the outcomes of the multi-table transaction
+// benchmark across its writer and reader threads.
+type TransactionStats struct {
+	Committed    atomic.Int64
+	Failed       atomic.Int64
+	Unsupported  atomic.Int64 // Commits the adapter or the catalog rejected as an unknown operation
+	PartialReads atomic.Int64
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"sync"
+)
+
+// KeyVersions records the entity version of every key of a pool that the
+// catalog last acknowledged, so threads that update the same key send its
+// current version instead of a per-thread counter, and the order in which the
+// keys were last written, for the latest distribution. Sent to an agent, the
+// versions are copied and shared by the threads of that agent only.
+type KeyVersions struct {
+	mu       sync.Mutex
+	versions map[string]int
+	recent   []string // The keys of the pool, the most recently written last
+}
+
+// NewKeyVersions tracks the keys of a pool, which count as written in the
+// order of the pool by its creation.
+func NewKeyVersions(pool []string) *KeyVersions {
+	return &KeyVersions{versions: make(map[string]int), recent: slices.Clone(pool)}
+}
+
+// Version returns the last acknowledged version of the key, or 1 for a key
+// that was not updated yet, which is the version of a new entity.
+func (v *KeyVersions) Version(key string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if version, ok := v.versions[key]; ok {
+		return version
+	}
+	return 1
+}
+
+// Acknowledged records a successful update of the key, which makes it the most
+// recently written key, with the version it returned, 0 if none. A response
+// that arrives after a later one moves neither the version nor the key back.
+func (v *KeyVersions) Acknowledged(key string, version int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if version > 0 && version < v.versions[key] {
+		return
+	}
+	if version > 0 {
+		v.versions[key] = version
+	}
+	if i := slices.Index(v.recent, key); i >= 0 {
+		v.recent = append(slices.Delete(v.recent, i, i+1), key)
+	}
+}
+
+// Recent returns the key with the given rank by recency, where rank 0 is the
+// most recently written key.
+func (v *KeyVersions) Recent(rank int) string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.recent[len(v.recent)-1-rank]
+}
+
+// encodedKeyVersions is the state of KeyVersions that is sent to an agent.
+type encodedKeyVersions struct {
+	Versions map[string]int
+	Recent   []string
+}
+
+func (v *KeyVersions) GobEncode() ([]byte, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(encodedKeyVersions{Versions: v.versions, Recent: v.recent})
+	return buffer.Bytes(), err
+}
+
+func (v *KeyVersions) GobDecode(data []byte) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var encoded encodedKeyVersions
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&encoded); err != nil {
+		return err
+	}
+	v.versions, v.recent = encoded.Versions, encoded.Recent
+	if v.versions == nil {
+		v.versions = make(map[string]int)
+	}
+	return nil
+}
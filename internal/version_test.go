@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"benchmark/internal/common"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// acknowledgement is a successful update of a key with the version it returned.
+type acknowledgement struct {
+	key     string
+	version int
+}
+
+func TestKeyVersions(t *testing.T) {
+	tests := []struct {
+		name         string
+		acknowledged []acknowledgement
+		wantVersions map[string]int
+		wantRecent   []string // Most recently written first
+	}{
+		{
+			name:         "created",
+			wantVersions: map[string]int{"a": 1, "b": 1, "c": 1},
+			wantRecent:   []string{"c", "b", "a"},
+		},
+		{
+			name:         "updated",
+			acknowledged: []acknowledgement{{"a", 2}, {"b", 2}, {"a", 3}},
+			wantVersions: map[string]int{"a": 3, "b": 2, "c": 1},
+			wantRecent:   []string{"a", "b", "c"},
+		},
+		{
+			name:         "late response",
+			acknowledged: []acknowledgement{{"a", 3}, {"b", 2}, {"a", 2}},
+			wantVersions: map[string]int{"a": 3, "b": 2, "c": 1},
+			wantRecent:   []string{"b", "a", "c"},
+		},
+		{
+			name:         "unversioned",
+			acknowledged: []acknowledgement{{"a", 0}},
+			wantVersions: map[string]int{"a": 1, "b": 1, "c": 1},
+			wantRecent:   []string{"a", "c", "b"},
+		},
+		{
+			name:         "not in the pool",
+			acknowledged: []acknowledgement{{"d", 2}},
+			wantVersions: map[string]int{"a": 1, "b": 1, "c": 1, "d": 2},
+			wantRecent:   []string{"c", "b", "a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			versions := NewKeyVersions([]string{"a", "b", "c"})
+			for _, ack := range test.acknowledged {
+				versions.Acknowledged(ack.key, ack.version)
+			}
+			for key, want := range test.wantVersions {
+				if got := versions.Version(key); got != want {
+					t.Errorf("Version(%q) = %d, want %d", key, got, want)
+				}
+			}
+			for rank, want := range test.wantRecent {
+				if got := versions.Recent(rank); got != want {
+					t.Errorf("Recent(%d) = %q, want %q", rank, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestLatestDistribution checks that the latest distribution chooses the
+// recently written keys of a pool most often, whatever their place in the pool.
+func TestLatestDistribution(t *testing.T) {
+	const keys = 100
+	const draws = 100000
+
+	pool := make([]string, keys)
+	for i := range pool {
+		pool[i] = fmt.Sprintf("key-%d", i)
+	}
+	versions := NewKeyVersions(pool)
+	// Early keys of the pool are updated last
+	versions.Acknowledged("key-10", 2)
+	versions.Acknowledged("key-20", 2)
+
+	chooser := common.NewKeyChooser(common.Distribution{Type: common.LatestDistribution, Theta: 0.99}, keys)
+	rng := rand.New(rand.NewPCG(1, 2))
+	counts := make(map[string]int)
+	for range draws {
+		counts[versions.Recent(chooser.Next(rng))]++
+	}
+
+	// By recency: key-20, key-10, then the pool from its end
+	order := []string{"key-20", "key-10", "key-99", "key-98"}
+	for i := 1; i < len(order); i++ {
+		if counts[order[i-1]] <= counts[order[i]] {
+			t.Errorf("%s drawn %d times, want more than %s with %d", order[i-1], counts[order[i-1]], order[i], counts[order[i]])
+		}
+	}
+	if counts["key-20"] < counts["key-0"]*10 {
+		t.Errorf("key-20 drawn %d times, want far more than the oldest key-0 with %d", counts["key-20"], counts["key-0"])
+	}
+
+	// A write makes a key the most recent one
+	versions.Acknowledged("key-0", 2)
+	clear(counts)
+	for range draws {
+		counts[versions.Recent(chooser.Next(rng))]++
+	}
+	if counts["key-0"] <= counts["key-20"] {
+		t.Errorf("key-0 drawn %d times after its update, want more than key-20 with %d", counts["key-0"], counts["key-20"])
+	}
+}
@@ -3,22 +3,38 @@ package internal
 import (
 	"benchmark/internal/common"
 	"context"
+	"encoding/json"
 	"errors"
-	"github.com/google/uuid"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type WorkerFunc func(w *Worker)
 type Worker struct {
-	Ctx     context.Context
-	Func    WorkerFunc
-	Catalog Catalog
-	Client  *http.Client
-	Logger  *common.RoutineBatchLogger
-	Step    int
-	Params  map[string]interface{}
+	Ctx        context.Context
+	Func       WorkerFunc
+	Catalog    Catalog
+	Client     *http.Client
+	Logger     *common.RoutineBatchLogger
+	Step       int
+	Params     map[string]interface{}
+	Rand       *rand.Rand         // Per-thread source for the random choices of the worker
+	Schedule   *common.Schedule   // Iterations of the last run, set by Run
+	Monitor    *Monitor           // Counts the logged requests for the dashboard, if set
+	Metrics    *ExperimentMetrics // Counts the logged requests for Prometheus, if set
+	Entity     common.EntityType  // Entity type of the experiment, whose name the spans of the operations record
+	entityName string
 }
 
 func NewWorker(client *http.Client, catalog Catalog, logger *common.RoutineBatchLogger, params map[string]interface{}, workerFunc WorkerFunc) *Worker {
@@ -35,22 +51,43 @@ func NewWorker(client *http.Client, catalog Catalog, logger *common.RoutineBatch
 		Params:  paramsCopy,
 		Ctx:     context.Background(),
 		Func:    workerFunc,
+		Rand:    rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
 	}
 }
 
 func (w *Worker) Log(resp *http.Response, err error) {
+	w.LogBody(resp, err)
+}
+
+// LogBody logs the response like Log and returns its status code and body, so
+// workers can inspect a response after it has been logged.
+func (w *Worker) LogBody(resp *http.Response, err error) (int, []byte) {
 	method := "NONE"
 	if err != nil {
+		var urlErr *url.Error
 		switch {
 		case errors.Is(err, context.Canceled):
 			w.Logger.Log("ERROR", method, w.Step, 0, err.Error())
-		case err.(*url.Error).Timeout():
+		case errors.As(err, &urlErr) && urlErr.Timeout():
 			w.Logger.Log("ERROR", method, w.Step, 0, err.Error())
+			// Requests cut off by the end of the benchmark did not time out
+			if w.Metrics != nil && w.Ctx.Err() == nil {
+				w.Metrics.Timeout()
+			}
 		default:
 			w.Logger.Log("ERROR", method, w.Step, 0, err.Error())
 		}
+		if w.Monitor != nil {
+			w.Monitor.Record(method, 0, 0)
+		}
+		if w.Metrics != nil {
+			w.Metrics.Record(method, 0, 0)
+		}
+		if w.Logger.Summary != nil {
+			w.Logger.Summary.Record(method, 0, 0)
+		}
 
-		return
+		return 0, nil
 	}
 	statusCode := resp.StatusCode
 	method = resp.Request.Method
@@ -60,7 +97,7 @@ func (w *Worker) Log(resp *http.Response, err error) {
 
 	if err != nil {
 		w.Logger.Log("ERROR", method, w.Step, statusCode, err.Error())
-		return
+		return statusCode, nil
 	}
 
 	level := "ERROR"
@@ -68,42 +105,163 @@ func (w *Worker) Log(resp *http.Response, err error) {
 		level = "INFO"
 	}
 
-	w.Logger.Log(level, method, w.Step, statusCode, string(body))
+	stats := common.StatsOf(resp.Request)
+	w.Logger.LogRequest(level, method, w.Step, statusCode, stats, string(body))
+	var latency time.Duration
+	if stats != nil {
+		latency = stats.Latency
+	}
+	if w.Monitor != nil {
+		w.Monitor.Record(method, statusCode, latency)
+	}
+	if w.Metrics != nil {
+		w.Metrics.Record(method, statusCode, latency)
+	}
+	if w.Logger.Summary != nil {
+		w.Logger.Summary.Record(method, statusCode, latency)
+	}
+	return statusCode, body
+}
+
+// LogCheckedBody logs the response like LogBody, but with its body in summary
+// mode as well, as the consistency checks of the logs read it.
+func (w *Worker) LogCheckedBody(resp *http.Response, err error) (int, []byte) {
+	w.Logger.KeepBody = true
+	defer func() { w.Logger.KeepBody = false }()
+	return w.LogBody(resp, err)
+}
+
+// NewName returns a new entity name drawn from the PRNG of the worker. The
+// first name of an operation is the entity that its span records.
+func (w *Worker) NewName() string {
+	name := common.NewName(w.Rand, w.Logger.ExperimentID)
+	if w.entityName == "" {
+		w.entityName = name
+	}
+	return name
 }
 
 func (w *Worker) IncrementStep() {
 	w.Step++
 }
 
+// Retry moves on to the next step of an operation that is retried after a conflict.
+func (w *Worker) Retry() {
+	w.Step++
+	if w.Metrics != nil {
+		w.Metrics.Retry()
+	}
+}
+
 func (w *Worker) Run(ctx context.Context) {
 	w.Ctx = ctx
 	// EntityVersion counter for update operations
 	entityVersion := 1
 	w.Params["entityVersion"] = entityVersion
+	payload, hasPayload := w.Params["payload"].(common.Payload)
+	thinkTime, hasThinkTime := w.Params["thinkTime"].(common.ThinkTime)
+	rate, _ := w.Params["rate"].(float64)
+	w.Schedule = common.NewSchedule(rate)
+	pool, hasPool := w.Params["pool"].([]string)
+	var keyChooser *common.KeyChooser
+	var distribution common.Distribution
+	if hasPool {
+		distribution = w.Params["distribution"].(common.Distribution)
+		keyChooser = common.NewKeyChooser(distribution, len(pool))
+	}
+	versions, hasVersions := w.Params["versions"].(*KeyVersions)
 	for ctx.Err() == nil {
-		w.Func(w)
+		w.Logger.Behind = w.Schedule.Wait(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+		// Every iteration creates and updates entities with a freshly drawn payload
+		if hasPayload {
+			payload.Generate(w.Rand, w.Params)
+		}
+		// and targets the next key chosen from the pool, with the version of the key all threads share.
+		// The choice is made here rather than by a wrapping worker, as agents only run registered workers by name
+		if hasPool {
+			index := keyChooser.Next(w.Rand)
+			key := pool[index]
+			if hasVersions && distribution.Type == common.LatestDistribution {
+				key = versions.Recent(index)
+			}
+			w.Params[w.Params["keyParam"].(string)] = key
+			if hasVersions {
+				w.Params["entityVersion"] = versions.Version(key)
+			}
+		}
+		w.RunOperation(ctx)
 		w.Step++
 		entityVersion++
 		w.Params["entityVersion"] = entityVersion
+		if hasThinkTime {
+			common.Sleep(ctx, thinkTime.Draw(w.Rand))
+		}
+	}
+	w.Schedule.Stop()
+	w.Logger.Behind = false
+
+	if rate > 0 || hasThinkTime {
+		details, _ := json.Marshal(map[string]interface{}{
+			"target_rate":   rate,
+			"achieved_rate": w.Schedule.AchievedRate(),
+			"iterations":    w.Schedule.Iterations,
+			"behind":        w.Schedule.Behind,
+		})
+		w.Logger.Log("INFO", "PACING", w.Step, 0, string(details))
+	}
+}
+
+// RunOperation runs the worker function once in a span of its own, which is the
+// parent of the spans of its requests.
+func (w *Worker) RunOperation(ctx context.Context) {
+	// Operations on an existing entity find its name in the params, e.g. catalogName
+	w.entityName, _ = w.Params[string(w.Entity)+"Name"].(string)
+
+	name := WorkerName(w.Func)
+	name = name[strings.LastIndex(name, ".")+1:]
+	operationCtx, span := common.StartSpan(common.WithSpanAttributes(ctx, w.spanAttributes), name, trace.SpanKindInternal)
+	defer span.End()
+
+	w.Ctx = operationCtx
+	w.Func(w)
+	w.Ctx = ctx
+	if w.entityName != "" {
+		span.SetAttributes(common.EntityNameAttribute.String(w.entityName))
 	}
 }
 
+// spanAttributes returns the attributes of the spans of the current step.
+func (w *Worker) spanAttributes() []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		common.ExperimentIDAttribute.String(w.Logger.ExperimentID),
+		common.ThreadIDAttribute.Int(w.Logger.TheadID),
+		common.StepIDAttribute.Int(w.Step),
+	}
+	if w.entityName != "" {
+		attributes = append(attributes, common.EntityNameAttribute.String(w.entityName))
+	}
+	return attributes
+}
+
 func CreateCatalogWorker(w *Worker) {
-	catalogName := uuid.NewString()
-	resp, err := w.Catalog.CreateCatalog(w.Ctx, catalogName)
+	catalogName := w.NewName()
+	resp, err := w.Catalog.CreateCatalog(w.Ctx, catalogName, w.Params)
 	w.Log(resp, err)
 }
 
 func CreatePrincipalWorker(w *Worker) {
-	catalogName := uuid.NewString()
-	resp, err := w.Catalog.CreatePrincipal(w.Ctx, catalogName)
+	catalogName := w.NewName()
+	resp, err := w.Catalog.CreatePrincipal(w.Ctx, catalogName, w.Params)
 	w.Log(resp, err)
 }
 
 func CreateSchemaWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
-	schemaName := uuid.NewString()
-	resp, err := w.Catalog.CreateSchema(w.Ctx, catalogName, schemaName)
+	schemaName := w.NewName()
+	resp, err := w.Catalog.CreateSchema(w.Ctx, catalogName, schemaName, w.Params)
 	w.Log(resp, err)
 }
 
@@ -111,8 +269,8 @@ func CreateTableWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	tableName := uuid.NewString()
-	resp, err := w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, tableName)
+	tableName := w.NewName()
+	resp, err := w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, tableName, w.Params)
 	w.Log(resp, err)
 }
 
@@ -120,8 +278,8 @@ func CreateViewWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	viewName := uuid.NewString()
-	resp, err := w.Catalog.CreateView(w.Ctx, catalogName, schemaName, viewName)
+	viewName := w.NewName()
+	resp, err := w.Catalog.CreateView(w.Ctx, catalogName, schemaName, viewName, w.Params)
 	w.Log(resp, err)
 }
 
@@ -129,8 +287,8 @@ func CreateFunctionWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	functionName := uuid.NewString()
-	resp, err := w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, functionName)
+	functionName := w.NewName()
+	resp, err := w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, functionName, w.Params)
 	w.Log(resp, err)
 }
 
@@ -138,8 +296,8 @@ func CreateModelWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	modelName := uuid.NewString()
-	resp, err := w.Catalog.CreateModel(w.Ctx, catalogName, schemaName, modelName)
+	modelName := w.NewName()
+	resp, err := w.Catalog.CreateModel(w.Ctx, catalogName, schemaName, modelName, w.Params)
 	w.Log(resp, err)
 }
 
@@ -147,17 +305,17 @@ func CreateVolumeWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	volumeName := uuid.NewString()
-	resp, err := w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, volumeName)
+	volumeName := w.NewName()
+	resp, err := w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, volumeName, w.Params)
 	w.Log(resp, err)
 
 }
 
 func CreateDeleteCatalogWorker(w *Worker) {
 
-	catalogName := uuid.NewString()
+	catalogName := w.NewName()
 
-	resp, err := w.Catalog.CreateCatalog(w.Ctx, catalogName)
+	resp, err := w.Catalog.CreateCatalog(w.Ctx, catalogName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -167,8 +325,8 @@ func CreateDeleteCatalogWorker(w *Worker) {
 }
 
 func CreateDeletePrincipalWorker(w *Worker) {
-	principalName := uuid.NewString()
-	resp, err := w.Catalog.CreatePrincipal(w.Ctx, principalName)
+	principalName := w.NewName()
+	resp, err := w.Catalog.CreatePrincipal(w.Ctx, principalName, w.Params)
 	w.Log(resp, err)
 
 	resp, err = w.Catalog.DeletePrincipal(w.Ctx, principalName)
@@ -178,9 +336,9 @@ func CreateDeletePrincipalWorker(w *Worker) {
 func CreateDeleteSchemaWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 
-	schemaName := uuid.NewString()
+	schemaName := w.NewName()
 
-	resp, err := w.Catalog.CreateSchema(w.Ctx, catalogName, schemaName)
+	resp, err := w.Catalog.CreateSchema(w.Ctx, catalogName, schemaName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -193,8 +351,8 @@ func CreateDeleteTableWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
 
-	tableName := uuid.NewString()
-	resp, err := w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, tableName)
+	tableName := w.NewName()
+	resp, err := w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, tableName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -206,9 +364,9 @@ func CreateDeleteTableWorker(w *Worker) {
 func CreateDeleteViewWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
-	viewName := uuid.NewString()
+	viewName := w.NewName()
 
-	resp, err := w.Catalog.CreateView(w.Ctx, catalogName, schemaName, viewName)
+	resp, err := w.Catalog.CreateView(w.Ctx, catalogName, schemaName, viewName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -220,9 +378,9 @@ func CreateDeleteViewWorker(w *Worker) {
 func CreateDeleteFunctionWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
-	functionName := uuid.NewString()
+	functionName := w.NewName()
 
-	resp, err := w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, functionName)
+	resp, err := w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, functionName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -234,9 +392,9 @@ func CreateDeleteFunctionWorker(w *Worker) {
 func CreateDeleteModelWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
-	modelName := uuid.NewString()
+	modelName := w.NewName()
 
-	resp, err := w.Catalog.CreateModel(w.Ctx, catalogName, schemaName, modelName)
+	resp, err := w.Catalog.CreateModel(w.Ctx, catalogName, schemaName, modelName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -248,9 +406,9 @@ func CreateDeleteModelWorker(w *Worker) {
 func CreateDeleteVolumeWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
 	schemaName := w.Params["schemaName"].(string)
-	volumeName := uuid.NewString()
+	volumeName := w.NewName()
 
-	resp, err := w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, volumeName)
+	resp, err := w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, volumeName, w.Params)
 	w.Log(resp, err)
 
 	w.IncrementStep()
@@ -266,8 +424,7 @@ func UpdateCatalogWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateCatalog(w.Ctx, catalogName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
-
+	w.logVersionedUpdate(catalogName, resp, err)
 }
 
 func UpdatePrincipalWorker(w *Worker) {
@@ -277,7 +434,29 @@ func UpdatePrincipalWorker(w *Worker) {
 	resp, err := w.Catalog.UpdatePrincipal(w.Ctx, principalName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.logVersionedUpdate(principalName, resp, err)
+}
+
+// logVersionedUpdate logs the response of an update that is sent with the
+// entity version. With shared key versions, a successful update and its version
+// are recorded for the next thread that chooses or updates the key.
+func (w *Worker) logVersionedUpdate(key string, resp *http.Response, err error) {
+	versions, ok := w.Params["versions"].(*KeyVersions)
+	if !ok {
+		w.Log(resp, err)
+		return
+	}
+
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode < 200 || statusCode > 299 {
+		return
+	}
+	var entity struct {
+		EntityVersion int `json:"entityVersion"`
+	}
+	// Catalogs that do not version the entity still record the update
+	_ = json.Unmarshal(body, &entity)
+	versions.Acknowledged(key, entity.EntityVersion)
 }
 
 func UpdateSchemaWorker(w *Worker) {
@@ -288,7 +467,7 @@ func UpdateSchemaWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateSchema(w.Ctx, catalogName, schemaName, map[string]interface{}{
 		"entityVersion": entityVersion},
 	)
-	w.Log(resp, err)
+	w.logVersionedUpdate(schemaName, resp, err)
 }
 func UpdateTableWorker(w *Worker) {
 	catalogName := w.Params["catalogName"].(string)
@@ -299,7 +478,7 @@ func UpdateTableWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateTable(w.Ctx, catalogName, schemaName, tableName, map[string]interface{}{
 		"entityVersion": entityVersion},
 	)
-	w.Log(resp, err)
+	w.logVersionedUpdate(tableName, resp, err)
 }
 
 func UpdateViewWorker(w *Worker) {
@@ -311,7 +490,7 @@ func UpdateViewWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateView(w.Ctx, catalogName, schemaName, viewName, map[string]interface{}{
 		"entityVersion": entityVersion},
 	)
-	w.Log(resp, err)
+	w.logVersionedUpdate(viewName, resp, err)
 }
 
 func UpdateModelWorker(w *Worker) {
@@ -323,7 +502,7 @@ func UpdateModelWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateModel(w.Ctx, catalogName, schemaName, modelName, map[string]interface{}{
 		"entityVersion": entityVersion},
 	)
-	w.Log(resp, err)
+	w.logVersionedUpdate(modelName, resp, err)
 }
 
 func UpdateVolumeWorker(w *Worker) {
@@ -335,7 +514,7 @@ func UpdateVolumeWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateVolume(w.Ctx, catalogName, schemaName, volumeName, map[string]interface{}{
 		"entityVersion": entityVersion},
 	)
-	w.Log(resp, err)
+	w.logVersionedUpdate(volumeName, resp, err)
 }
 
 func UpdateGetCatalogWorker(w *Worker) {
@@ -345,12 +524,12 @@ func UpdateGetCatalogWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateCatalog(w.Ctx, catalogName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	w.IncrementStep()
 
 	resp, err = w.Catalog.GetCatalog(w.Ctx, catalogName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetPrincipalWorker(w *Worker) {
@@ -360,12 +539,12 @@ func UpdateGetPrincipalWorker(w *Worker) {
 	resp, err := w.Catalog.UpdatePrincipal(w.Ctx, principalName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	w.IncrementStep()
 
 	resp, err = w.Catalog.GetPrincipal(w.Ctx, principalName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetSchemaWorker(w *Worker) {
@@ -376,12 +555,12 @@ func UpdateGetSchemaWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateSchema(w.Ctx, catalogName, schemaName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	w.IncrementStep()
 
 	resp, err = w.Catalog.GetSchema(w.Ctx, catalogName, schemaName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetTableWorker(w *Worker) {
@@ -392,12 +571,12 @@ func UpdateGetTableWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateTable(w.Ctx, catalogName, schemaName, tableName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	entityVersion++
 
 	resp, err = w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetViewWorker(w *Worker) {
@@ -408,12 +587,12 @@ func UpdateGetViewWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateView(w.Ctx, catalogName, schemaName, viewName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	entityVersion++
 
 	resp, err = w.Catalog.GetView(w.Ctx, catalogName, schemaName, viewName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetModelWorker(w *Worker) {
@@ -424,12 +603,12 @@ func UpdateGetModelWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateModel(w.Ctx, catalogName, schemaName, modelName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	entityVersion++
 
 	resp, err = w.Catalog.GetModel(w.Ctx, catalogName, schemaName, modelName)
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 }
 
 func UpdateGetVolumeWorker(w *Worker) {
@@ -441,14 +620,1700 @@ func UpdateGetVolumeWorker(w *Worker) {
 	resp, err := w.Catalog.UpdateVolume(w.Ctx, catalogName, schemaName, volumeName, map[string]interface{}{
 		"entityVersion": entityVersion,
 	})
-	w.Log(resp, err)
+	w.LogCheckedBody(resp, err)
 
 	entityVersion++
 
 	resp, err = w.Catalog.GetVolume(w.Ctx, catalogName, schemaName, volumeName)
+	w.LogCheckedBody(resp, err)
+}
+
+// ConflictUpdateCatalogWorker reads the catalog, and writes it back with the
+// entity version it read. Updates rejected with 409 Conflict are retried from
+// the read until they succeed or the benchmark ends.
+func ConflictUpdateCatalogWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	for w.Ctx.Err() == nil {
+		resp, err := w.Catalog.GetCatalog(w.Ctx, catalogName)
+		entityVersion, ok := w.logEntityVersion(resp, err)
+		if !ok {
+			return
+		}
+
+		w.IncrementStep()
+
+		resp, err = w.Catalog.UpdateCatalog(w.Ctx, catalogName, map[string]interface{}{
+			"entityVersion": entityVersion,
+		})
+		statusCode, _ := w.LogCheckedBody(resp, err)
+		w.logBaseVersion(statusCode, entityVersion)
+		if statusCode != http.StatusConflict {
+			return
+		}
+
+		w.Retry()
+	}
+}
+
+// ConflictUpdatePrincipalWorker is the principal counterpart of
+// ConflictUpdateCatalogWorker.
+func ConflictUpdatePrincipalWorker(w *Worker) {
+	principalName := w.Params["principalName"].(string)
+
+	for w.Ctx.Err() == nil {
+		resp, err := w.Catalog.GetPrincipal(w.Ctx, principalName)
+		entityVersion, ok := w.logEntityVersion(resp, err)
+		if !ok {
+			return
+		}
+
+		w.IncrementStep()
+
+		resp, err = w.Catalog.UpdatePrincipal(w.Ctx, principalName, map[string]interface{}{
+			"entityVersion": entityVersion,
+		})
+		statusCode, _ := w.LogCheckedBody(resp, err)
+		w.logBaseVersion(statusCode, entityVersion)
+		if statusCode != http.StatusConflict {
+			return
+		}
+
+		w.Retry()
+	}
+}
+
+// logBaseVersion logs the entity version that an update was sent with, so that
+// updates based on the same version can be found in the logs.
+func (w *Worker) logBaseVersion(statusCode int, entityVersion int) {
+	level := "ERROR"
+	if statusCode >= 200 && statusCode <= 299 {
+		level = "INFO"
+	}
+	details, _ := json.Marshal(map[string]interface{}{"base_version": entityVersion})
+	w.Logger.Log(level, "BASE_VERSION", w.Step, statusCode, string(details))
+}
+
+// logEntityVersion logs a GET response and parses the entityVersion field
+// from its body.
+func (w *Worker) logEntityVersion(resp *http.Response, err error) (int, bool) {
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return 0, false
+	}
+
+	var entity struct {
+		EntityVersion int `json:"entityVersion"`
+	}
+	if err := json.Unmarshal(body, &entity); err != nil {
+		return 0, false
+	}
+	return entity.EntityVersion, true
+}
+
+// PropertyUpdateCatalogWorker reads the full property map of the shared
+// catalog, increments the property owned by the thread and writes the map back.
+func PropertyUpdateCatalogWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	propertyReadModifyWrite(w, func() (*http.Response, error) {
+		return w.Catalog.GetCatalog(w.Ctx, catalogName)
+	}, func(params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.UpdateCatalog(w.Ctx, catalogName, params)
+	})
+}
+
+func PropertyUpdateSchemaWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	propertyReadModifyWrite(w, func() (*http.Response, error) {
+		return w.Catalog.GetSchema(w.Ctx, catalogName, schemaName)
+	}, func(params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.UpdateSchema(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func PropertyUpdateTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableName := w.Params["tableName"].(string)
+
+	propertyReadModifyWrite(w, func() (*http.Response, error) {
+		return w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
+	}, func(params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.UpdateTable(w.Ctx, catalogName, schemaName, tableName, params)
+	})
+}
+
+// PropertyAuditCatalogWorker compares the final value of every thread's
+// property with the number of writes the catalog acknowledged for it.
+func PropertyAuditCatalogWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	resp, err := w.Catalog.GetCatalog(w.Ctx, catalogName)
+	propertyAudit(w, resp, err)
+}
+
+func PropertyAuditSchemaWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	resp, err := w.Catalog.GetSchema(w.Ctx, catalogName, schemaName)
+	propertyAudit(w, resp, err)
+}
+
+func PropertyAuditTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableName := w.Params["tableName"].(string)
+
+	resp, err := w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
+	propertyAudit(w, resp, err)
+}
+
+func propertyReadModifyWrite(w *Worker, get func() (*http.Response, error), update func(params map[string]interface{}) (*http.Response, error)) {
+	propertyKey := w.Params["propertyKey"].(string)
+	acknowledged := w.Params["acknowledged"].(map[string]*atomic.Int64)
+
+	statusCode, body := w.LogCheckedBody(get())
+	if statusCode != http.StatusOK {
+		return
+	}
+	properties, entityVersion, err := parseProperties(body)
+	if err != nil {
+		return
+	}
+
+	value, _ := strconv.Atoi(properties[propertyKey])
+	properties[propertyKey] = strconv.Itoa(value + 1)
+
+	w.IncrementStep()
+
+	statusCode, _ = w.LogBody(update(map[string]interface{}{
+		"properties":    properties,
+		"entityVersion": entityVersion,
+	}))
+	if statusCode >= 200 && statusCode <= 299 {
+		acknowledged[propertyKey].Add(1)
+	}
+}
+
+func propertyAudit(w *Worker, resp *http.Response, err error) {
+	acknowledged := w.Params["acknowledged"].(map[string]*atomic.Int64)
+
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return
+	}
+	properties, _, err := parseProperties(body)
+	if err != nil {
+		w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, err.Error())
+		return
+	}
+
+	for propertyKey, writes := range acknowledged {
+		w.IncrementStep()
+
+		value, _ := strconv.Atoi(properties[propertyKey])
+		acknowledgedWrites := writes.Load()
+		result, _ := json.Marshal(map[string]interface{}{
+			"property":     propertyKey,
+			"value":        value,
+			"acknowledged": acknowledgedWrites,
+		})
+
+		// A value below the acknowledged writes means another thread silently overwrote the property,
+		// a value above them that a write was applied although its response was lost
+		level := "INFO"
+		if int64(value) != acknowledgedWrites {
+			level = "ERROR"
+		}
+		w.Logger.Log(level, "AUDIT", w.Step, statusCode, string(result))
+	}
+}
+
+// parseProperties returns the property map and entity version of a catalog,
+// namespace or table response. Iceberg tables nest their properties in the
+// table metadata.
+func parseProperties(body []byte) (map[string]string, int, error) {
+	var entity struct {
+		Properties    map[string]string `json:"properties"`
+		EntityVersion int               `json:"entityVersion"`
+		Metadata      struct {
+			Properties map[string]string `json:"properties"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &entity); err != nil {
+		return nil, 0, err
+	}
+
+	properties := entity.Properties
+	if properties == nil {
+		properties = entity.Metadata.Properties
+	}
+	if properties == nil {
+		properties = make(map[string]string)
+	}
+	return properties, entity.EntityVersion, nil
+}
+
+// RaceCreateCatalogWorker creates the same catalog name as every other racing
+// thread in each round. The last thread to finish the round checks that only
+// one create succeeded and which creator's payload the catalog ended up with.
+func RaceCreateCatalogWorker(w *Worker) {
+	raceCreate(w, func(name string, params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.CreateCatalog(w.Ctx, name, params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetCatalog(w.Ctx, name)
+	})
+}
+
+func RaceCreatePrincipalWorker(w *Worker) {
+	raceCreate(w, func(name string, params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.CreatePrincipal(w.Ctx, name, params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetPrincipal(w.Ctx, name)
+	})
+}
+
+func RaceCreateSchemaWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	raceCreate(w, func(name string, params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.CreateSchema(w.Ctx, catalogName, name, params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetSchema(w.Ctx, catalogName, name)
+	})
+}
+
+func RaceCreateTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	raceCreate(w, func(name string, params map[string]interface{}) (*http.Response, error) {
+		return w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, name, params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetTable(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func raceCreate(w *Worker, create func(name string, params map[string]interface{}) (*http.Response, error), get func(name string) (*http.Response, error)) {
+	race := w.Params["race"].(*Race)
+	raceName := w.Params["raceName"].(string)
+
+	round, ok := race.Start(w.Ctx)
+	if !ok {
+		return
+	}
+	name := fmt.Sprintf("%s-%d", raceName, round)
+
+	// Tags the payload with the creator, so the follow-up GET tells which create won
+	creator := strconv.Itoa(w.Logger.TheadID)
+	resp, err := create(name, map[string]interface{}{
+		"properties": map[string]string{"creator": creator},
+	})
+	statusCode, _ := w.LogBody(resp, err)
+
+	results, last := race.Finish(round, w.Logger.TheadID, statusCode)
+	if !last || w.Ctx.Err() != nil {
+		return
+	}
+
+	successes := make([]int, 0, 1)
+	conflicts := 0
+	for threadID, statusCode := range results {
+		switch {
+		case statusCode >= 200 && statusCode <= 299:
+			successes = append(successes, threadID)
+		case statusCode == http.StatusConflict:
+			conflicts++
+		}
+	}
+	sort.Ints(successes)
+
+	w.IncrementStep()
+
+	winner := ""
+	resp, err = get(name)
+	if statusCode, body := w.LogCheckedBody(resp, err); statusCode == http.StatusOK {
+		if properties, _, err := parseProperties(body); err == nil {
+			winner = properties["creator"]
+		}
+	}
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"name":      name,
+		"round":     round,
+		"successes": successes,
+		"conflicts": conflicts,
+		"winner":    winner,
+	})
+
+	level := "INFO"
+	if len(successes) != 1 || conflicts != race.Racers-1 || winner != strconv.Itoa(successes[0]) {
+		level = "ERROR"
+	}
+	w.Logger.Log(level, "AUDIT", w.Step, 0, string(result))
+}
+
+// ChurnParentWorker deletes and re-creates the schema the other threads create
+// children in, or its catalog when the parent parameter is "catalog".
+func ChurnParentWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tracker := w.Params["tracker"].(*ParentTracker)
+	parent, _ := w.Params["parent"].(string)
+
+	var resp *http.Response
+	var err error
+	index := tracker.BeginDelete()
+	if parent == "catalog" {
+		resp, err = w.Catalog.DeleteCatalog(w.Ctx, catalogName)
+	} else {
+		resp, err = w.Catalog.DeleteSchema(w.Ctx, catalogName, schemaName)
+	}
+	if statusCode, _ := w.LogBody(resp, err); statusCode >= 200 && statusCode <= 299 {
+		tracker.DeleteSucceeded(index)
+	}
+
+	tracker.BeginRecreate()
+	if parent == "catalog" {
+		w.IncrementStep()
+
+		resp, err = w.Catalog.CreateCatalog(w.Ctx, catalogName, nil)
+		w.Log(resp, err)
+
+		// A re-created Polaris catalog starts without the table privileges of its catalog_admin role
+		for _, privilege := range []string{"TABLE_WRITE_DATA", "TABLE_READ_DATA"} {
+			w.IncrementStep()
+
+			resp, err = w.Catalog.GrantPermissionCatalog(w.Ctx, catalogName, map[string]interface{}{
+				"privilege": privilege,
+			})
+			w.Log(resp, err)
+		}
+	}
+
+	w.IncrementStep()
+
+	resp, err = w.Catalog.CreateSchema(w.Ctx, catalogName, schemaName, nil)
 	w.Log(resp, err)
 }
 
+func ParentChildTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildCreate(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, name, w.Params)
+	})
+}
+
+func ParentChildViewWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildCreate(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateView(w.Ctx, catalogName, schemaName, name, w.Params)
+	})
+}
+
+func ParentChildFunctionWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildCreate(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, name, w.Params)
+	})
+}
+
+func ParentChildVolumeWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildCreate(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, name, w.Params)
+	})
+}
+
+// ParentChildAuditTableWorker lists and gets every acknowledged table, and
+// flags tables that are still visible after a later parent delete succeeded.
+func ParentChildAuditTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildAudit(w, func() ([]*http.Response, error) {
+		return w.Catalog.ListTables(w.Ctx, catalogName, schemaName, map[string]interface{}{})
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetTable(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func ParentChildAuditViewWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildAudit(w, func() ([]*http.Response, error) {
+		return w.Catalog.ListViews(w.Ctx, catalogName, schemaName, map[string]interface{}{})
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetView(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func ParentChildAuditFunctionWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildAudit(w, func() ([]*http.Response, error) {
+		return w.Catalog.ListFunctions(w.Ctx, catalogName, schemaName, map[string]interface{}{})
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetFunction(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func ParentChildAuditVolumeWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	parentChildAudit(w, func() ([]*http.Response, error) {
+		return w.Catalog.ListVolumes(w.Ctx, catalogName, schemaName, map[string]interface{}{})
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetVolume(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func parentChildCreate(w *Worker, create func(name string) (*http.Response, error)) {
+	tracker := w.Params["tracker"].(*ParentTracker)
+
+	name := w.NewName()
+	gap := tracker.Gap()
+	if statusCode, _ := w.LogBody(create(name)); statusCode >= 200 && statusCode <= 299 {
+		tracker.ChildCreated(name, gap)
+	}
+}
+
+func parentChildAudit(w *Worker, list func() ([]*http.Response, error), get func(name string) (*http.Response, error)) {
+	tracker := w.Params["tracker"].(*ParentTracker)
+
+	listed := make(map[string]bool)
+	responses, err := list()
+	if err != nil {
+		w.Log(nil, err)
+	}
+	for _, resp := range responses {
+		_, body := w.LogCheckedBody(resp, nil)
+		for _, name := range parseNames(body) {
+			listed[name] = true
+		}
+		w.IncrementStep()
+	}
+
+	for _, name := range tracker.Children() {
+		statusCode, _ := w.LogBody(get(name))
+		exists := statusCode == http.StatusOK
+		parentDeleted := tracker.Orphaned(name)
+		withoutParent := tracker.CreatedWithoutParent(name)
+
+		result, _ := json.Marshal(map[string]interface{}{
+			"name":                   name,
+			"exists":                 exists,
+			"listed":                 listed[name],
+			"parent_deleted":         parentDeleted,
+			"created_without_parent": withoutParent,
+		})
+
+		// A child acknowledged before a successful parent delete must be gone, also from a re-created parent,
+		// and no child can be created while its parent is deleted
+		level := "INFO"
+		if (parentDeleted && (exists || listed[name])) || withoutParent {
+			level = "ERROR"
+		}
+		w.Logger.Log(level, "AUDIT", w.Step, statusCode, string(result))
+		w.IncrementStep()
+	}
+}
+
+// parseNames returns the entity names of a list response page from either
+// catalog. Iceberg REST namespaces are returned by their last level.
+func parseNames(body []byte) []string {
+	type named struct {
+		Name string `json:"name"`
+	}
+	var page struct {
+		Identifiers []named    `json:"identifiers"`
+		Namespaces  [][]string `json:"namespaces"`
+		Catalogs    []named    `json:"catalogs"`
+		Principals  []named    `json:"principals"`
+		Schemas     []named    `json:"schemas"`
+		Tables      []named    `json:"tables"`
+		Functions   []named    `json:"functions"`
+		Models      []named    `json:"registered_models"`
+		Volumes     []named    `json:"volumes"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0)
+	for _, entities := range [][]named{page.Identifiers, page.Catalogs, page.Principals, page.Schemas, page.Tables, page.Functions, page.Models, page.Volumes} {
+		for _, entity := range entities {
+			names = append(names, entity.Name)
+		}
+	}
+	for _, namespace := range page.Namespaces {
+		if len(namespace) > 0 {
+			names = append(names, namespace[len(namespace)-1])
+		}
+	}
+	return names
+}
+
+// RecreateCatalogWorker cycles the same catalog name through create, get,
+// delete and get, and checks that the deleted catalog is gone and that the
+// re-created catalog is not served from a stale copy.
+func RecreateCatalogWorker(w *Worker) {
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateCatalog(w.Ctx, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetCatalog(w.Ctx, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteCatalog(w.Ctx, name)
+	})
+}
+
+func RecreatePrincipalWorker(w *Worker) {
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreatePrincipal(w.Ctx, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetPrincipal(w.Ctx, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeletePrincipal(w.Ctx, name)
+	})
+}
+
+func RecreateSchemaWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateSchema(w.Ctx, catalogName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetSchema(w.Ctx, catalogName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteSchema(w.Ctx, catalogName, name)
+	})
+}
+
+func RecreateTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateTable(w.Ctx, catalogName, schemaName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetTable(w.Ctx, catalogName, schemaName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteTable(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func RecreateViewWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateView(w.Ctx, catalogName, schemaName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetView(w.Ctx, catalogName, schemaName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteView(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func RecreateFunctionWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateFunction(w.Ctx, catalogName, schemaName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetFunction(w.Ctx, catalogName, schemaName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteFunction(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func RecreateModelWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateModel(w.Ctx, catalogName, schemaName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetModel(w.Ctx, catalogName, schemaName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteModel(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func RecreateVolumeWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	recreateCycle(w, func(name string) (*http.Response, error) {
+		return w.Catalog.CreateVolume(w.Ctx, catalogName, schemaName, name, w.Params)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.GetVolume(w.Ctx, catalogName, schemaName, name)
+	}, func(name string) (*http.Response, error) {
+		return w.Catalog.DeleteVolume(w.Ctx, catalogName, schemaName, name)
+	})
+}
+
+func recreateCycle(w *Worker, create func(name string) (*http.Response, error), get func(name string) (*http.Response, error), del func(name string) (*http.Response, error)) {
+	name := w.Params["entityName"].(string)
+	// Identity of the previous incarnation, kept in the worker's own copy of the params
+	previous, _ := w.Params["identity"].(string)
+
+	// Entities with properties are tagged, as not every entity has a server-assigned ID, next to the generated properties
+	payloadProperties, _ := w.Params["properties"].(map[string]string)
+	properties := make(map[string]string, len(payloadProperties)+1)
+	for k, v := range payloadProperties {
+		properties[k] = v
+	}
+	properties["incarnation"] = w.NewName()
+	w.Params["properties"] = properties
+	statusCode, _ := w.LogBody(create(name))
+	created := statusCode >= 200 && statusCode <= 299
+
+	w.IncrementStep()
+
+	statusCode, body := w.LogCheckedBody(get(name))
+	if created && statusCode == http.StatusOK {
+		identity := parseIdentity(body)
+		if identity != "" && identity == previous {
+			w.logViolation("stale_get_after_recreate", name, statusCode, identity)
+		}
+		w.Params["identity"] = identity
+	}
+
+	w.IncrementStep()
+
+	statusCode, _ = w.LogBody(del(name))
+	deleted := statusCode >= 200 && statusCode <= 299
+
+	w.IncrementStep()
+
+	statusCode, body = w.LogCheckedBody(get(name))
+	if deleted && statusCode != http.StatusNotFound && w.Ctx.Err() == nil {
+		w.logViolation("get_after_delete", name, statusCode, parseIdentity(body))
+	}
+}
+
+func (w *Worker) logViolation(check string, name string, statusCode int, identity string) {
+	result, _ := json.Marshal(map[string]interface{}{
+		"check":    check,
+		"name":     name,
+		"identity": identity,
+	})
+	w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, string(result))
+}
+
+// parseIdentity returns what distinguishes one incarnation of an entity from
+// another with the same name: its incarnation property, its server-assigned ID,
+// or else its creation time.
+func parseIdentity(body []byte) string {
+	if properties, _, err := parseProperties(body); err == nil && properties["incarnation"] != "" {
+		return properties["incarnation"]
+	}
+
+	var entity struct {
+		ID              string `json:"id"`
+		SchemaID        string `json:"schema_id"`
+		TableID         string `json:"table_id"`
+		FunctionID      string `json:"function_id"`
+		VolumeID        string `json:"volume_id"`
+		ClientID        string `json:"clientId"`
+		CreateTimestamp int64  `json:"createTimestamp"`
+		CreatedAt       int64  `json:"created_at"`
+		MetadataLoc     string `json:"metadata-location"`
+		Metadata        struct {
+			TableUUID string `json:"table-uuid"`
+			ViewUUID  string `json:"view-uuid"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &entity); err != nil {
+		return ""
+	}
+
+	for _, id := range []string{entity.ID, entity.SchemaID, entity.TableID, entity.FunctionID, entity.VolumeID, entity.ClientID, entity.Metadata.TableUUID, entity.Metadata.ViewUUID, entity.MetadataLoc} {
+		if id != "" {
+			return id
+		}
+	}
+	if entity.CreateTimestamp != 0 {
+		return strconv.FormatInt(entity.CreateTimestamp, 10)
+	}
+	if entity.CreatedAt != 0 {
+		return strconv.FormatInt(entity.CreatedAt, 10)
+	}
+	return ""
+}
+
+// PaginationListCatalogsWorker lists all catalogs page by page, and checks that
+// every pre-populated catalog appears exactly once.
+func PaginationListCatalogsWorker(w *Worker) {
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListCatalogs(w.Ctx, params)
+	})
+}
+
+func PaginationListSchemasWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListSchemas(w.Ctx, catalogName, params)
+	})
+}
+
+func PaginationListTablesWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListTables(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func PaginationListViewsWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListViews(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func PaginationListFunctionsWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListFunctions(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func PaginationListModelsWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListModels(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func PaginationListVolumesWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+
+	paginationList(w, func(params map[string]interface{}) ([]*http.Response, error) {
+		return w.Catalog.ListVolumes(w.Ctx, catalogName, schemaName, params)
+	})
+}
+
+func paginationList(w *Worker, list func(params map[string]interface{}) ([]*http.Response, error)) {
+	populated := w.Params["populated"].([]string)
+	pageSize := w.Params["pageSize"].(int)
+
+	// A listing that fails part-way returns the pages before the error, which are logged with it
+	responses, err := list(map[string]interface{}{"maxResults": pageSize})
+
+	seen := make(map[string]int)
+	tokenErrors := 0
+	for _, resp := range responses {
+		statusCode, body := w.LogCheckedBody(resp, nil)
+		if statusCode != http.StatusOK {
+			tokenErrors++
+		}
+		for _, name := range parseNames(body) {
+			seen[name]++
+		}
+		w.IncrementStep()
+	}
+	if err != nil {
+		w.Log(nil, err)
+		if w.Ctx.Err() != nil {
+			return
+		}
+		tokenErrors++
+	}
+
+	duplicates := 0
+	for _, count := range seen {
+		if count > 1 {
+			duplicates++
+		}
+	}
+
+	// Pre-populated entities existed for the whole listing, so each must appear exactly once
+	skips := 0
+	for _, name := range populated {
+		if seen[name] == 0 {
+			skips++
+		}
+	}
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"pages":        len(responses),
+		"page_size":    pageSize,
+		"entities":     len(seen),
+		"duplicates":   duplicates,
+		"skips":        skips,
+		"token_errors": tokenErrors,
+	})
+
+	level := "INFO"
+	if duplicates > 0 || skips > 0 || tokenErrors > 0 {
+		level = "ERROR"
+	}
+	w.Logger.Log(level, "AUDIT", w.Step, 0, string(result))
+}
+
+// CommitTableWorker appends a metadata-only snapshot to the shared table on top
+// of the current snapshot of its main branch. Commits rejected with 409
+// Conflict are retried from the read until they succeed or the benchmark ends.
+func CommitTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableName := w.Params["tableName"].(string)
+	committed := w.Params["committed"].(*sync.Map)
+
+	for w.Ctx.Err() == nil {
+		resp, err := w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
+		statusCode, body := w.LogCheckedBody(resp, err)
+		if statusCode != http.StatusOK {
+			return
+		}
+		metadata, err := parseTableMetadata(body)
+		if err != nil {
+			return
+		}
+
+		w.IncrementStep()
+
+		snapshotID := w.Rand.Int64()
+		snapshot := map[string]interface{}{
+			"snapshot-id":     snapshotID,
+			"sequence-number": metadata.LastSequenceNumber + 1,
+			"timestamp-ms":    time.Now().UnixMilli(),
+			"manifest-list":   fmt.Sprintf("%s/metadata/snap-%d.avro", metadata.Location, snapshotID),
+			"summary":         map[string]string{"operation": "append"},
+			"schema-id":       metadata.CurrentSchemaID,
+		}
+		if metadata.CurrentSnapshotID != nil {
+			snapshot["parent-snapshot-id"] = *metadata.CurrentSnapshotID
+		}
+
+		resp, err = w.Catalog.UpdateTable(w.Ctx, catalogName, schemaName, tableName, map[string]interface{}{
+			"requirements": []map[string]interface{}{
+				{"type": "assert-ref-snapshot-id", "ref": "main", "snapshot-id": metadata.CurrentSnapshotID},
+			},
+			"updates": []map[string]interface{}{
+				{"action": "add-snapshot", "snapshot": snapshot},
+				{"action": "set-snapshot-ref", "ref-name": "main", "type": "branch", "snapshot-id": snapshotID},
+			},
+		})
+		statusCode, _ = w.LogBody(resp, err)
+		if statusCode >= 200 && statusCode <= 299 {
+			committed.Store(snapshotID, true)
+		}
+		if statusCode != http.StatusConflict {
+			return
+		}
+
+		w.Retry()
+	}
+}
+
+// CommitAuditTableWorker checks that the snapshots of the table form a single
+// linear chain from the current snapshot, and that no acknowledged commit is missing.
+func CommitAuditTableWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableName := w.Params["tableName"].(string)
+	committed := w.Params["committed"].(*sync.Map)
+
+	resp, err := w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return
+	}
+	metadata, err := parseTableMetadata(body)
+	if err != nil {
+		w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, err.Error())
+		return
+	}
+
+	parents := make(map[int64]*int64, len(metadata.Snapshots))
+	for _, snapshot := range metadata.Snapshots {
+		parents[snapshot.SnapshotID] = snapshot.ParentSnapshotID
+	}
+
+	// Walks the chain back from the current snapshot, every snapshot must be on it exactly once
+	chain := 0
+	visited := make(map[int64]bool, len(parents))
+	for id := metadata.CurrentSnapshotID; id != nil && !visited[*id]; id = parents[*id] {
+		if _, exists := parents[*id]; !exists {
+			break
+		}
+		visited[*id] = true
+		chain++
+	}
+
+	acknowledged := 0
+	lost := 0
+	committed.Range(func(key, _ interface{}) bool {
+		acknowledged++
+		if !visited[key.(int64)] {
+			lost++
+		}
+		return true
+	})
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"snapshots":    len(metadata.Snapshots),
+		"chain":        chain,
+		"snapshot_log": len(metadata.SnapshotLog),
+		"acknowledged": acknowledged,
+		"lost":         lost,
+	})
+
+	level := "INFO"
+	if chain != len(metadata.Snapshots) || len(metadata.SnapshotLog) != chain || lost > 0 {
+		level = "ERROR"
+	}
+	w.IncrementStep()
+	w.Logger.Log(level, "AUDIT", w.Step, statusCode, string(result))
+}
+
+type tableMetadata struct {
+	Location           string `json:"location"`
+	CurrentSchemaID    int    `json:"current-schema-id"`
+	LastSequenceNumber int64  `json:"last-sequence-number"`
+	CurrentSnapshotID  *int64 `json:"current-snapshot-id"`
+	Snapshots          []struct {
+		SnapshotID       int64  `json:"snapshot-id"`
+		ParentSnapshotID *int64 `json:"parent-snapshot-id"`
+	} `json:"snapshots"`
+	SnapshotLog []struct {
+		SnapshotID int64 `json:"snapshot-id"`
+	} `json:"snapshot-log"`
+}
+
+// parseTableMetadata returns the Iceberg metadata of a load table response.
+// A current snapshot ID of -1 means the table has no snapshot yet.
+func parseTableMetadata(body []byte) (tableMetadata, error) {
+	var table struct {
+		Metadata tableMetadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &table); err != nil {
+		return tableMetadata{}, err
+	}
+	if id := table.Metadata.CurrentSnapshotID; id != nil && *id == -1 {
+		table.Metadata.CurrentSnapshotID = nil
+	}
+	return table.Metadata, nil
+}
+
+// TransactionWriterWorker sets the next value of the writer's own property on
+// all shared tables in a single multi-table transaction.
+func TransactionWriterWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableNames := w.Params["tableNames"].([]string)
+	writerKey := w.Params["writerKey"].(string)
+	stats := w.Params["stats"].(*TransactionStats)
+
+	// Only this thread writes its key, so the committed values increase monotonically
+	value, _ := w.Params["value"].(int)
+	value++
+
+	resp, err := w.Catalog.CommitTransaction(w.Ctx, catalogName, map[string]interface{}{
+		"schemaName": schemaName,
+		"tableNames": tableNames,
+		"updates": []map[string]interface{}{
+			{"action": "set-properties", "updates": map[string]string{writerKey: strconv.Itoa(value)}},
+		},
+	})
+	statusCode, _ := w.LogBody(resp, err)
+	switch {
+	case statusCode >= 200 && statusCode <= 299:
+		stats.Committed.Add(1)
+		w.Params["value"] = value
+	case errors.Is(err, common.ErrNotImplemented):
+		stats.Unsupported.Add(1)
+	case statusCode == http.StatusNotFound || statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented:
+		stats.Unsupported.Add(1)
+	default:
+		stats.Failed.Add(1)
+	}
+}
+
+// TransactionReaderWorker gets the first table, every other table and the
+// first table again. With atomic transactions, every writer's value on the
+// other tables lies between its two values on the first table.
+func TransactionReaderWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableNames := w.Params["tableNames"].([]string)
+	writerKeys := w.Params["writerKeys"].([]string)
+	stats := w.Params["stats"].(*TransactionStats)
+
+	order := append(append(make([]string, 0, len(tableNames)+1), tableNames...), tableNames[0])
+
+	reads := make([]map[string]string, 0, len(order))
+	for _, tableName := range order {
+		resp, err := w.Catalog.GetTable(w.Ctx, catalogName, schemaName, tableName)
+		statusCode, body := w.LogCheckedBody(resp, err)
+		if statusCode != http.StatusOK {
+			return
+		}
+		properties, _, err := parseProperties(body)
+		if err != nil {
+			return
+		}
+		reads = append(reads, properties)
+		w.IncrementStep()
+	}
+
+	first, last := reads[0], reads[len(reads)-1]
+	for _, writerKey := range writerKeys {
+		lower, _ := strconv.Atoi(first[writerKey])
+		upper, _ := strconv.Atoi(last[writerKey])
+
+		for i, properties := range reads[1 : len(reads)-1] {
+			value, _ := strconv.Atoi(properties[writerKey])
+			if value >= lower && value <= upper {
+				continue
+			}
+
+			stats.PartialReads.Add(1)
+			result, _ := json.Marshal(map[string]interface{}{
+				"writer": writerKey,
+				"table":  tableNames[i+1],
+				"value":  value,
+				"lower":  lower,
+				"upper":  upper,
+			})
+			w.Logger.Log("ERROR", "AUDIT", w.Step, 0, string(result))
+		}
+	}
+}
+
+// TransactionAuditWorker reports whether the catalog supports multi-table
+// transactions and how many reads observed a partially applied transaction.
+func TransactionAuditWorker(w *Worker) {
+	stats := w.Params["stats"].(*TransactionStats)
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"supported":     stats.Committed.Load() > 0,
+		"committed":     stats.Committed.Load(),
+		"failed":        stats.Failed.Load(),
+		"unsupported":   stats.Unsupported.Load(),
+		"partial_reads": stats.PartialReads.Load(),
+	})
+
+	level := "INFO"
+	if stats.PartialReads.Load() > 0 {
+		level = "ERROR"
+	}
+	w.Logger.Log(level, "AUDIT", w.Step, 0, string(result))
+}
+
+// ViewVersionWriterWorker adds a new version to the shared view and makes it
+// the current version. Every version carries a token in both its summary and
+// its SQL, so readers can tell whether the current version is internally consistent.
+func ViewVersionWriterWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	viewName := w.Params["viewName"].(string)
+
+	resp, err := w.Catalog.GetView(w.Ctx, catalogName, schemaName, viewName)
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return
+	}
+	metadata, err := parseViewMetadata(body)
+	if err != nil {
+		return
+	}
+	w.checkViewMetadata(metadata, statusCode)
+
+	w.IncrementStep()
+
+	versionID := 0
+	schemaID := 0
+	for _, version := range metadata.Versions {
+		versionID = max(versionID, version.VersionID)
+		if version.VersionID == metadata.CurrentVersionID {
+			schemaID = version.SchemaID
+		}
+	}
+
+	token := w.NewName()
+	version := map[string]interface{}{
+		"version-id":   versionID + 1,
+		"timestamp-ms": time.Now().UnixMilli(),
+		"schema-id":    schemaID,
+		"summary":      map[string]string{"token": token, "writer": strconv.Itoa(w.Logger.TheadID)},
+		"representations": []map[string]string{
+			{"type": "sql", "sql": viewVersionSQL(token), "dialect": "ansi"},
+		},
+		"default-catalog":   catalogName,
+		"default-namespace": []string{schemaName},
+	}
+
+	// -1 refers to the version added in the same commit, whatever ID the server assigns it
+	resp, err = w.Catalog.UpdateView(w.Ctx, catalogName, schemaName, viewName, map[string]interface{}{
+		"requirements": []map[string]interface{}{
+			{"type": "assert-view-uuid", "uuid": metadata.ViewUUID},
+		},
+		"updates": []map[string]interface{}{
+			{"action": "add-view-version", "view-version": version},
+			{"action": "set-current-view-version", "view-version-id": -1},
+		},
+	})
+	w.Log(resp, err)
+}
+
+// ViewVersionReaderWorker loads the shared view and checks its version metadata.
+func ViewVersionReaderWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	viewName := w.Params["viewName"].(string)
+
+	resp, err := w.Catalog.GetView(w.Ctx, catalogName, schemaName, viewName)
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return
+	}
+	metadata, err := parseViewMetadata(body)
+	if err != nil {
+		w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, err.Error())
+		return
+	}
+	w.checkViewMetadata(metadata, statusCode)
+
+	// The current version only ever moves forward, so a thread must never observe it going back
+	if previous, ok := w.Params["currentVersionID"].(int); ok && metadata.CurrentVersionID < previous {
+		w.logCheck("current_version_regressed", statusCode, map[string]interface{}{
+			"previous": previous,
+			"current":  metadata.CurrentVersionID,
+		})
+	}
+	w.Params["currentVersionID"] = metadata.CurrentVersionID
+}
+
+type viewMetadata struct {
+	ViewUUID         string `json:"view-uuid"`
+	CurrentVersionID int    `json:"current-version-id"`
+	Versions         []struct {
+		VersionID       int               `json:"version-id"`
+		SchemaID        int               `json:"schema-id"`
+		Summary         map[string]string `json:"summary"`
+		Representations []struct {
+			Type string `json:"type"`
+			Sql  string `json:"sql"`
+		} `json:"representations"`
+	} `json:"versions"`
+	VersionLog []struct {
+		VersionID   int   `json:"version-id"`
+		TimestampMs int64 `json:"timestamp-ms"`
+	} `json:"version-log"`
+	Schemas []struct {
+		SchemaID int `json:"schema-id"`
+	} `json:"schemas"`
+}
+
+// parseViewMetadata returns the Iceberg metadata of a load view response.
+func parseViewMetadata(body []byte) (viewMetadata, error) {
+	var view struct {
+		Metadata viewMetadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &view); err != nil {
+		return viewMetadata{}, err
+	}
+	return view.Metadata, nil
+}
+
+func viewVersionSQL(token string) string {
+	return fmt.Sprintf("SELECT '%s' AS token", token)
+}
+
+// checkViewMetadata logs a violation if the current version is missing, its
+// schema is missing, its SQL does not match its own summary, or the version
+// log is not strictly increasing and ending at the current version.
+func (w *Worker) checkViewMetadata(metadata viewMetadata, statusCode int) {
+	schemas := make(map[int]bool, len(metadata.Schemas))
+	for _, schema := range metadata.Schemas {
+		schemas[schema.SchemaID] = true
+	}
+
+	found := false
+	for _, version := range metadata.Versions {
+		if version.VersionID != metadata.CurrentVersionID {
+			continue
+		}
+		found = true
+
+		if !schemas[version.SchemaID] {
+			w.logCheck("current_schema_missing", statusCode, map[string]interface{}{
+				"version_id": version.VersionID,
+				"schema_id":  version.SchemaID,
+			})
+		}
+
+		// The initial version was not written by this benchmark and carries no token
+		token, ok := version.Summary["token"]
+		if !ok {
+			break
+		}
+		for _, representation := range version.Representations {
+			if representation.Sql != viewVersionSQL(token) {
+				w.logCheck("current_version_inconsistent", statusCode, map[string]interface{}{
+					"version_id": version.VersionID,
+					"token":      token,
+					"sql":        representation.Sql,
+				})
+			}
+		}
+	}
+	if !found {
+		w.logCheck("current_version_missing", statusCode, map[string]interface{}{
+			"version_id": metadata.CurrentVersionID,
+		})
+	}
+
+	for i := 1; i < len(metadata.VersionLog); i++ {
+		if metadata.VersionLog[i].VersionID <= metadata.VersionLog[i-1].VersionID {
+			w.logCheck("version_log_not_monotonic", statusCode, map[string]interface{}{
+				"previous": metadata.VersionLog[i-1].VersionID,
+				"next":     metadata.VersionLog[i].VersionID,
+			})
+		}
+	}
+	if n := len(metadata.VersionLog); n > 0 && metadata.VersionLog[n-1].VersionID != metadata.CurrentVersionID {
+		w.logCheck("version_log_not_current", statusCode, map[string]interface{}{
+			"last":    metadata.VersionLog[n-1].VersionID,
+			"current": metadata.CurrentVersionID,
+		})
+	}
+}
+
+// logCheck logs a failed consistency check of an audit, named by check, with
+// the details that identify the entity it failed on.
+func (w *Worker) logCheck(check string, statusCode int, details map[string]interface{}) {
+	details["check"] = check
+	result, _ := json.Marshal(details)
+	w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, string(result))
+}
+
+// ModelVersionWorker creates a new version of the shared model and finalizes it.
+// Acknowledged versions are recorded in "versions", a version number handed out
+// twice is logged as a violation.
+func ModelVersionWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	modelName := w.Params["modelName"].(string)
+	versions := w.Params["versions"].(*sync.Map)
+
+	resp, err := w.Catalog.CreateModelVersion(w.Ctx, catalogName, schemaName, modelName, map[string]interface{}{
+		"runId": w.NewName(),
+	})
+	statusCode, body := w.LogCheckedBody(resp, err)
+	if statusCode != http.StatusOK {
+		return
+	}
+
+	var created struct {
+		Version int64 `json:"version"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return
+	}
+	if _, loaded := versions.LoadOrStore(created.Version, false); loaded {
+		result, _ := json.Marshal(map[string]interface{}{
+			"check":   "duplicate_version",
+			"version": created.Version,
+		})
+		w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, string(result))
+	}
+
+	w.IncrementStep()
+
+	resp, err = w.Catalog.FinalizeModelVersion(w.Ctx, catalogName, schemaName, modelName, created.Version)
+	statusCode, _ = w.LogBody(resp, err)
+	if statusCode == http.StatusOK {
+		versions.Store(created.Version, true)
+	}
+}
+
+// ModelVersionAuditWorker lists the versions of the shared model and checks that
+// they are unique and gap-free, and that every finalized version is listed as ready.
+func ModelVersionAuditWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	modelName := w.Params["modelName"].(string)
+	versions := w.Params["versions"].(*sync.Map)
+
+	responses, err := w.Catalog.ListModelVersions(w.Ctx, catalogName, schemaName, modelName, w.Params)
+	if err != nil || len(responses) == 0 {
+		w.Log(nil, err)
+		return
+	}
+
+	listed := make(map[int64]string)
+	duplicates := 0
+	for _, resp := range responses {
+		statusCode, body := w.LogCheckedBody(resp, nil)
+		w.IncrementStep()
+		if statusCode != http.StatusOK {
+			return
+		}
+
+		var page struct {
+			ModelVersions []struct {
+				Version int64  `json:"version"`
+				Status  string `json:"status"`
+			} `json:"model_versions"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, err.Error())
+			return
+		}
+		for _, version := range page.ModelVersions {
+			if _, exists := listed[version.Version]; exists {
+				duplicates++
+			}
+			listed[version.Version] = version.Status
+		}
+	}
+
+	// Version numbers start at 1, so every number up to the highest one must be listed
+	highest := int64(0)
+	acknowledged := 0
+	finalized := 0
+	missing := 0
+	versions.Range(func(key, value interface{}) bool {
+		version := key.(int64)
+		highest = max(highest, version)
+		acknowledged++
+		if value.(bool) {
+			finalized++
+			if listed[version] != "READY" {
+				missing++
+			}
+		}
+		return true
+	})
+	for version := range listed {
+		highest = max(highest, version)
+	}
+	gaps := 0
+	for version := int64(1); version <= highest; version++ {
+		if _, exists := listed[version]; !exists {
+			gaps++
+		}
+	}
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"listed":       len(listed),
+		"acknowledged": acknowledged,
+		"finalized":    finalized,
+		"duplicates":   duplicates,
+		"gaps":         gaps,
+		"missing":      missing,
+	})
+
+	level := "INFO"
+	if duplicates > 0 || gaps > 0 || missing > 0 {
+		level = "ERROR"
+	}
+	w.Logger.Log(level, "AUDIT", w.Step, http.StatusOK, string(result))
+}
+
+// PermissionChurnWorker toggles one grant per iteration, cycling through the
+// catalog privilege, the catalog role of the principal role, and the principal
+// role of the principal, each revoked and then granted again.
+func PermissionChurnWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	catalogRole := w.Params["catalogRole"].(string)
+	principalRole := w.Params["principalRole"].(string)
+	principalName := w.Params["principalName"].(string)
+	privilege := w.Params["privilege"].(string)
+	tracker := w.Params["tracker"].(*PermissionTracker)
+
+	grants := []string{"privilege", "catalog_role", "principal_role"}
+	cycle, _ := w.Params["cycle"].(int)
+	w.Params["cycle"] = cycle + 1
+
+	grant := grants[(cycle/2)%len(grants)]
+	granted := !tracker.Granted(grant)
+
+	tracker.BeginChange()
+	var resp *http.Response
+	var err error
+	switch {
+	case grant == "privilege" && granted:
+		resp, err = w.Catalog.GrantPermissionCatalog(w.Ctx, catalogName, map[string]interface{}{"privilege": privilege, "catalogRole": catalogRole})
+	case grant == "privilege":
+		resp, err = w.Catalog.RevokePermissionCatalog(w.Ctx, catalogName, map[string]interface{}{"privilege": privilege, "catalogRole": catalogRole})
+	case grant == "catalog_role" && granted:
+		resp, err = w.Catalog.GrantCatalogRole(w.Ctx, principalRole, catalogName, catalogRole)
+	case grant == "catalog_role":
+		resp, err = w.Catalog.RevokeCatalogRole(w.Ctx, principalRole, catalogName, catalogRole)
+	case granted:
+		resp, err = w.Catalog.GrantPrincipalRole(w.Ctx, principalName, principalRole)
+	default:
+		resp, err = w.Catalog.RevokePrincipalRole(w.Ctx, principalName, principalRole)
+	}
+	statusCode, _ := w.LogBody(resp, err)
+	tracker.EndChange(grant, granted, statusCode >= 200 && statusCode <= 299)
+}
+
+// PermissionAccessWorker loads the shared table as the benchmark principal and
+// compares the access decision with the grant state at the time. Decisions that
+// overlap a grant change are transitional and not compared.
+func PermissionAccessWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	schemaName := w.Params["schemaName"].(string)
+	tableName := w.Params["tableName"].(string)
+	token := w.Params["token"].(string)
+	tracker := w.Params["tracker"].(*PermissionTracker)
+
+	before, expected, stable := tracker.State()
+	resp, err := w.Catalog.GetTable(common.WithToken(w.Ctx, token), catalogName, schemaName, tableName)
+	statusCode, _ := w.LogBody(resp, err)
+	after, _, _ := tracker.State()
+
+	var allowed bool
+	switch statusCode {
+	case http.StatusOK:
+		allowed = true
+	case http.StatusForbidden:
+		allowed = false
+	default:
+		return
+	}
+
+	switch {
+	case !stable || before != after:
+		tracker.Transitional.Add(1)
+	case allowed == expected && allowed:
+		tracker.Allowed.Add(1)
+	case allowed == expected:
+		tracker.Denied.Add(1)
+	default:
+		check := "stale_denied"
+		if allowed {
+			check = "stale_allowed"
+			tracker.StaleAllowed.Add(1)
+		} else {
+			tracker.StaleDenied.Add(1)
+		}
+		result, _ := json.Marshal(map[string]interface{}{
+			"check":    check,
+			"expected": expected,
+		})
+		w.Logger.Log("ERROR", "AUDIT", w.Step, statusCode, string(result))
+	}
+}
+
+// PermissionAuditWorker reports how many access decisions matched the grant state.
+func PermissionAuditWorker(w *Worker) {
+	tracker := w.Params["tracker"].(*PermissionTracker)
+
+	result, _ := json.Marshal(map[string]interface{}{
+		"allowed":       tracker.Allowed.Load(),
+		"denied":        tracker.Denied.Load(),
+		"transitional":  tracker.Transitional.Load(),
+		"stale_allowed": tracker.StaleAllowed.Load(),
+		"stale_denied":  tracker.StaleDenied.Load(),
+	})
+
+	level := "INFO"
+	if tracker.StaleAllowed.Load() > 0 || tracker.StaleDenied.Load() > 0 {
+		level = "ERROR"
+	}
+	w.Logger.Log(level, "AUDIT", w.Step, 0, string(result))
+}
+
+// NamespaceTreeWorker builds a namespace tree of the configured depth and
+// fan-out below the shared root namespace and tears it down again. Every level
+// is listed after it was built and after its children were deleted, and a
+// namespace that still has children must not be deletable.
+func NamespaceTreeWorker(w *Worker) {
+	catalogName := w.Params["catalogName"].(string)
+	rootNamespace := w.Params["rootNamespace"].(string)
+	depth := w.Params["depth"].(int)
+	fanOut := w.Params["fanOut"].(int)
+
+	treeRoot := common.NamespacePath(rootNamespace, w.NewName())
+	resp, err := w.Catalog.CreateSchema(w.Ctx, catalogName, treeRoot, nil)
+	if statusCode, _ := w.LogBody(resp, err); statusCode != http.StatusOK {
+		return
+	}
+
+	// Builds the tree level by level, so every parent exists before its children
+	levels := [][]string{{treeRoot}}
+	children := make(map[string][]string)
+	for level := 1; level < depth && w.Ctx.Err() == nil; level++ {
+		next := make([]string, 0)
+		for _, parent := range levels[level-1] {
+			for range fanOut {
+				w.IncrementStep()
+				child := common.NamespacePath(parent, w.NewName())
+				resp, err = w.Catalog.CreateSchema(w.Ctx, catalogName, child, nil)
+				if statusCode, _ := w.LogBody(resp, err); statusCode == http.StatusOK {
+					children[parent] = append(children[parent], child)
+					next = append(next, child)
+				}
+			}
+		}
+		levels = append(levels, next)
+	}
+
+	for parent, expected := range children {
+		w.IncrementStep()
+		w.checkChildNamespaces(w.Ctx, catalogName, parent, expected)
+	}
+
+	if len(children[treeRoot]) > 0 {
+		w.IncrementStep()
+		resp, err = w.Catalog.DeleteSchema(w.Ctx, catalogName, treeRoot)
+		if statusCode, _ := w.LogBody(resp, err); statusCode >= 200 && statusCode <= 299 {
+			w.logCheck("delete_non_empty", statusCode, map[string]interface{}{"namespace": treeRoot})
+		}
+	}
+
+	// The tree is torn down even if the run ended while it was built, within a deadline of its own
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(w.Ctx), time.Minute)
+	defer cancel()
+
+	// Tears the tree down from the leaves, every parent must be empty once its children are gone
+	for level := len(levels) - 1; level >= 0; level-- {
+		for _, namespace := range levels[level] {
+			if len(children[namespace]) > 0 {
+				w.IncrementStep()
+				w.checkChildNamespaces(ctx, catalogName, namespace, nil)
+			}
+			w.IncrementStep()
+			resp, err = w.Catalog.DeleteSchema(ctx, catalogName, namespace)
+			w.Log(resp, err)
+		}
+	}
+
+	w.IncrementStep()
+	resp, err = w.Catalog.GetSchema(ctx, catalogName, treeRoot)
+	if statusCode, _ := w.LogBody(resp, err); statusCode >= 200 && statusCode <= 299 {
+		w.logCheck("get_after_delete", statusCode, map[string]interface{}{"namespace": treeRoot})
+	}
+}
+
+// checkChildNamespaces lists the children of a namespace and logs a violation
+// for every expected child that is missing and every child that is unexpected.
+func (w *Worker) checkChildNamespaces(ctx context.Context, catalogName string, parent string, expected []string) {
+	responses, err := w.Catalog.ListSchemas(ctx, catalogName, map[string]interface{}{"parentNamespace": parent})
+	if len(responses) == 0 || err != nil {
+		w.Log(nil, err)
+		return
+	}
+
+	listed := make(map[string]bool)
+	statusCode := 0
+	for _, resp := range responses {
+		var body []byte
+		statusCode, body = w.LogCheckedBody(resp, nil)
+		if statusCode != http.StatusOK {
+			return
+		}
+		for _, name := range parseNames(body) {
+			listed[name] = true
+		}
+	}
+
+	// Listings name the last level of every child
+	for _, child := range expected {
+		levels := strings.Split(child, common.NamespaceSeparator)
+		name := levels[len(levels)-1]
+		if !listed[name] {
+			w.logCheck("list_missing", statusCode, map[string]interface{}{"parent": parent, "namespace": child})
+		}
+		delete(listed, name)
+	}
+	for name := range listed {
+		w.logCheck("list_unexpected", statusCode, map[string]interface{}{"parent": parent, "namespace": common.NamespacePath(parent, name)})
+	}
+}
+
+// ManifestReadCatalogWorker loads a populated catalog and lists all catalogs.
+func ManifestReadCatalogWorker(w *Worker) {
+	entry, ok := w.Params["manifest"].(*Manifest).Sample(w.Rand, common.CatalogEntity)
+	if !ok {
+		return
+	}
+
+	resp, err := w.Catalog.GetCatalog(w.Ctx, entry.Catalog)
+	w.Log(resp, err)
+
+	w.IncrementStep()
+
+	responses, err := w.Catalog.ListCatalogs(w.Ctx, map[string]interface{}{})
+	w.logPages(responses, err)
+}
+
+// ManifestReadSchemaWorker loads a populated schema and lists the schemas of its catalog.
+func ManifestReadSchemaWorker(w *Worker) {
+	entry, ok := w.Params["manifest"].(*Manifest).Sample(w.Rand, common.SchemaEntity)
+	if !ok {
+		return
+	}
+
+	resp, err := w.Catalog.GetSchema(w.Ctx, entry.Catalog, entry.Schema)
+	w.Log(resp, err)
+
+	w.IncrementStep()
+
+	responses, err := w.Catalog.ListSchemas(w.Ctx, entry.Catalog, map[string]interface{}{})
+	w.logPages(responses, err)
+}
+
+// ManifestReadTableWorker loads a populated table and lists the tables of its schema.
+func ManifestReadTableWorker(w *Worker) {
+	entry, ok := w.Params["manifest"].(*Manifest).Sample(w.Rand, common.TableEntity)
+	if !ok {
+		return
+	}
+
+	resp, err := w.Catalog.GetTable(w.Ctx, entry.Catalog, entry.Schema, entry.Table)
+	w.Log(resp, err)
+
+	w.IncrementStep()
+
+	responses, err := w.Catalog.ListTables(w.Ctx, entry.Catalog, entry.Schema, map[string]interface{}{})
+	w.logPages(responses, err)
+}
+
+// logPages logs every page of a listing, unlike the list workers that only
+// log the last page.
+func (w *Worker) logPages(responses []*http.Response, err error) {
+	for _, resp := range responses {
+		w.Log(resp, nil)
+	}
+	if err != nil {
+		w.Log(nil, err)
+	}
+}
+
 func ListCatalogsWorker(w *Worker) {
 	responses, err := w.Catalog.ListCatalogs(w.Ctx, w.Params)
 	if len(responses) == 0 || err != nil {
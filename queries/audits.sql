@@ -0,0 +1,153 @@
+DROP TABLE IF EXISTS logs;
+DROP TABLE IF EXISTS experiments;
+
+
+-- The columns are listed, as fields that are omitted when empty may be missing from the sampled rows
+CREATE TABLE logs AS
+SELECT *
+FROM read_json('output/logs/*.jsonl', maximum_object_size=50000000, columns={
+    level: 'VARCHAR', experiment_id: 'UUID', thread_id: 'INTEGER', principal: 'VARCHAR', method: 'VARCHAR',
+    step_id: 'INTEGER', timestamp: 'TIMESTAMP', elapsed_ns: 'BIGINT', start_ns: 'BIGINT', agent: 'VARCHAR',
+    status_code: 'INTEGER', request_size: 'BIGINT', latency_ms: 'DOUBLE', behind_schedule: 'BOOLEAN', body: 'VARCHAR'
+});
+
+CREATE TABLE experiments AS
+SELECT *
+FROM read_json('output/experiments/*.json', columns={
+    id: 'UUID', catalog: 'VARCHAR', benchmark: 'INTEGER', threads: 'INTEGER', agents: 'VARCHAR[]', seed: 'UBIGINT',
+    start_timestamp: 'TIMESTAMP', end_timestamp: 'TIMESTAMP', anchor: 'TIMESTAMP',
+    clocks: 'STRUCT(agent VARCHAR, "offset" BIGINT, uncertainty BIGINT)[]', duration: 'BIGINT', entity: 'VARCHAR',
+    parent: 'VARCHAR', populate: 'INTEGER', page_size: 'INTEGER', depth: 'INTEGER', fan_out: 'INTEGER',
+    payload: 'STRUCT(columns STRUCT(min INTEGER, max INTEGER), nesting STRUCT(min INTEGER, max INTEGER), properties STRUCT(min INTEGER, max INTEGER), property_size STRUCT(min INTEGER, max INTEGER), comment STRUCT(min INTEGER, max INTEGER), representations STRUCT(min INTEGER, max INTEGER))',
+    populate_tree: 'STRUCT(catalogs INTEGER, schemas INTEGER, tables INTEGER)', manifest: 'VARCHAR', keys: 'INTEGER',
+    distribution: 'STRUCT(type VARCHAR, theta DOUBLE, hot_ops DOUBLE, hot_keys DOUBLE)',
+    think_time: 'STRUCT(type VARCHAR, mean BIGINT, min BIGINT, max BIGINT)', rate: 'DOUBLE', summary: 'BOOLEAN',
+    body_sample: 'DOUBLE', principals: 'INTEGER', principal_roles: 'BOOLEAN'
+});
+
+-- Select all failed audits
+SELECT ex.catalog, ex.entity, ex.benchmark, ex.threads, l.experiment_id, l.body
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE l.method = 'AUDIT' AND l.level = 'ERROR'
+ORDER BY ex.benchmark, ex.catalog, ex.entity, ex.threads;
+
+-- Lost property writes for each catalog, entity and thread count
+SELECT
+    ex.catalog,
+    ex.entity,
+    ex.threads,
+    SUM(json_extract(l.body, '$.acknowledged')::INTEGER) AS acknowledged_writes,
+    SUM(json_extract(l.body, '$.acknowledged')::INTEGER - json_extract(l.body, '$.value')::INTEGER) AS lost_writes
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 7 AND l.method = 'AUDIT'
+GROUP BY ex.catalog, ex.entity, ex.threads
+ORDER BY ex.catalog, ex.entity, ex.threads;
+
+-- Name-uniqueness rounds with more or less than one successful create
+SELECT
+    ex.catalog,
+    ex.entity,
+    ex.threads,
+    COUNT(*) AS rounds,
+    COUNT(*) FILTER (WHERE json_array_length(json_extract(l.body, '$.successes')) > 1) AS multiple_successes,
+    COUNT(*) FILTER (WHERE l.level = 'ERROR') AS failed_rounds
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 8 AND l.method = 'AUDIT'
+GROUP BY ex.catalog, ex.entity, ex.threads
+ORDER BY ex.catalog, ex.entity, ex.threads;
+
+-- Drop-and-recreate violations for each catalog and entity type
+SELECT
+    ex.catalog,
+    ex.entity,
+    json_extract_string(l.body, '$.check') AS check_name,
+    COUNT(*) AS violations
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 10 AND l.method = 'AUDIT' AND l.level = 'ERROR'
+GROUP BY ex.catalog, ex.entity, check_name
+ORDER BY ex.catalog, ex.entity, check_name;
+
+-- Pagination results for each catalog, entity and page size
+SELECT
+    ex.catalog,
+    ex.entity,
+    ex.page_size,
+    ex.threads,
+    COUNT(*) AS listings,
+    AVG(json_extract(l.body, '$.pages')::INTEGER) AS avg_pages,
+    SUM(json_extract(l.body, '$.duplicates')::INTEGER) AS duplicates,
+    SUM(json_extract(l.body, '$.skips')::INTEGER) AS skips,
+    SUM(json_extract(l.body, '$.token_errors')::INTEGER) AS token_errors
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 11 AND l.method = 'AUDIT'
+GROUP BY ex.catalog, ex.entity, ex.page_size, ex.threads
+ORDER BY ex.catalog, ex.entity, ex.page_size, ex.threads;
+
+-- Multi-table transaction support and partially applied transactions
+SELECT
+    ex.catalog,
+    ex.threads,
+    json_extract(l.body, '$.supported')::BOOLEAN AS supported,
+    json_extract(l.body, '$.committed')::INTEGER AS committed,
+    json_extract(l.body, '$.partial_reads')::INTEGER AS partial_reads
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 13 AND l.method = 'AUDIT' AND json_extract(l.body, '$.supported') IS NOT NULL
+ORDER BY ex.catalog, ex.threads;
+
+-- View version violations by check
+SELECT
+    ex.catalog,
+    ex.threads,
+    json_extract_string(l.body, '$.check') AS check_name,
+    count(*) AS violations
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 14 AND l.method = 'AUDIT'
+GROUP BY ex.catalog, ex.threads, check_name
+ORDER BY ex.catalog, ex.threads, check_name;
+
+-- Model version numbering and listing
+SELECT
+    ex.catalog,
+    ex.threads,
+    json_extract(l.body, '$.listed')::INTEGER AS listed,
+    json_extract(l.body, '$.finalized')::INTEGER AS finalized,
+    json_extract(l.body, '$.duplicates')::INTEGER AS duplicates,
+    json_extract(l.body, '$.gaps')::INTEGER AS gaps,
+    json_extract(l.body, '$.missing')::INTEGER AS missing
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 15 AND l.method = 'AUDIT' AND json_extract(l.body, '$.listed') IS NOT NULL
+ORDER BY ex.catalog, ex.threads;
+
+-- Access decisions against the grant state
+SELECT
+    ex.catalog,
+    ex.threads,
+    json_extract(l.body, '$.allowed')::INTEGER AS allowed,
+    json_extract(l.body, '$.denied')::INTEGER AS denied,
+    json_extract(l.body, '$.transitional')::INTEGER AS transitional,
+    json_extract(l.body, '$.stale_allowed')::INTEGER AS stale_allowed,
+    json_extract(l.body, '$.stale_denied')::INTEGER AS stale_denied
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 16 AND l.method = 'AUDIT' AND json_extract(l.body, '$.allowed') IS NOT NULL
+ORDER BY ex.catalog, ex.threads;
+
+-- Namespace tree violations by check
+SELECT
+    ex.catalog,
+    ex.threads,
+    json_extract_string(l.body, '$.check') AS check_name,
+    count(*) AS violations
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 17 AND l.method = 'AUDIT'
+GROUP BY ex.catalog, ex.threads, check_name
+ORDER BY ex.catalog, ex.threads, check_name;
@@ -0,0 +1,68 @@
+DROP TABLE IF EXISTS logs;
+DROP TABLE IF EXISTS experiments;
+
+
+-- The columns are listed, as fields that are omitted when empty may be missing from the sampled rows
+CREATE TABLE logs AS
+SELECT *
+FROM read_json('output/logs/*.jsonl', maximum_object_size=50000000, columns={
+    level: 'VARCHAR', experiment_id: 'UUID', thread_id: 'INTEGER', principal: 'VARCHAR', method: 'VARCHAR',
+    step_id: 'INTEGER', timestamp: 'TIMESTAMP', elapsed_ns: 'BIGINT', start_ns: 'BIGINT', agent: 'VARCHAR',
+    status_code: 'INTEGER', request_size: 'BIGINT', latency_ms: 'DOUBLE', behind_schedule: 'BOOLEAN', body: 'VARCHAR'
+});
+
+CREATE TABLE experiments AS
+SELECT *
+FROM read_json('output/experiments/*.json', columns={
+    id: 'UUID', catalog: 'VARCHAR', benchmark: 'INTEGER', threads: 'INTEGER', agents: 'VARCHAR[]', seed: 'UBIGINT',
+    start_timestamp: 'TIMESTAMP', end_timestamp: 'TIMESTAMP', anchor: 'TIMESTAMP',
+    clocks: 'STRUCT(agent VARCHAR, "offset" BIGINT, uncertainty BIGINT)[]', duration: 'BIGINT', entity: 'VARCHAR',
+    parent: 'VARCHAR', populate: 'INTEGER', page_size: 'INTEGER', depth: 'INTEGER', fan_out: 'INTEGER',
+    payload: 'STRUCT(columns STRUCT(min INTEGER, max INTEGER), nesting STRUCT(min INTEGER, max INTEGER), properties STRUCT(min INTEGER, max INTEGER), property_size STRUCT(min INTEGER, max INTEGER), comment STRUCT(min INTEGER, max INTEGER), representations STRUCT(min INTEGER, max INTEGER))',
+    populate_tree: 'STRUCT(catalogs INTEGER, schemas INTEGER, tables INTEGER)', manifest: 'VARCHAR', keys: 'INTEGER',
+    distribution: 'STRUCT(type VARCHAR, theta DOUBLE, hot_ops DOUBLE, hot_keys DOUBLE)',
+    think_time: 'STRUCT(type VARCHAR, mean BIGINT, min BIGINT, max BIGINT)', rate: 'DOUBLE', summary: 'BOOLEAN',
+    body_sample: 'DOUBLE', principals: 'INTEGER', principal_roles: 'BOOLEAN'
+});
+
+-- Conflict rate of the read-modify-write updates for each catalog, entity and thread count
+SELECT
+    ex.catalog,
+    ex.entity,
+    ex.threads,
+    COUNT(*) AS updates,
+    COUNT(*) FILTER (WHERE l.status_code = 409) AS conflicts,
+    COUNT(*) FILTER (WHERE l.status_code = 409) / COUNT(*) AS conflict_rate
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 6 AND l.method = 'PUT'
+GROUP BY ex.catalog, ex.entity, ex.threads
+ORDER BY ex.catalog, ex.entity, ex.threads;
+
+-- Lost updates: two successful updates that were both sent with the same entity version
+SELECT
+    l.experiment_id,
+    ex.entity,
+    json_extract(l.body, '$.base_version')::INTEGER AS base_version,
+    COUNT(*) AS successful_updates,
+    LIST(l.thread_id) AS threads
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 6 AND l.method = 'BASE_VERSION' AND l.level = 'INFO'
+GROUP BY l.experiment_id, ex.entity, base_version
+HAVING COUNT(*) > 1
+ORDER BY l.experiment_id, base_version;
+
+-- Commit throughput, conflict rate and retries to success of the snapshot commits
+SELECT
+    ex.catalog,
+    ex.threads,
+    COUNT(*) FILTER (WHERE l.level = 'INFO') AS commits,
+    COUNT(*) FILTER (WHERE l.level = 'INFO') / (ex.duration / 1e9) AS commits_per_second,
+    COUNT(*) FILTER (WHERE l.status_code = 409) / COUNT(*) AS conflict_rate,
+    COUNT(*) FILTER (WHERE l.status_code = 409) / NULLIF(COUNT(*) FILTER (WHERE l.level = 'INFO'), 0) AS retries_per_commit
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE ex.benchmark = 12 AND l.method = 'POST'
+GROUP BY ex.catalog, ex.threads, ex.duration
+ORDER BY ex.catalog, ex.threads;
@@ -0,0 +1,55 @@
+DROP TABLE IF EXISTS logs;
+DROP TABLE IF EXISTS experiments;
+
+
+-- The columns are listed, as fields that are omitted when empty may be missing from the sampled rows
+CREATE TABLE logs AS
+SELECT *
+FROM read_json('output/logs/*.jsonl', maximum_object_size=50000000, columns={
+    level: 'VARCHAR', experiment_id: 'UUID', thread_id: 'INTEGER', principal: 'VARCHAR', method: 'VARCHAR',
+    step_id: 'INTEGER', timestamp: 'TIMESTAMP', elapsed_ns: 'BIGINT', start_ns: 'BIGINT', agent: 'VARCHAR',
+    status_code: 'INTEGER', request_size: 'BIGINT', latency_ms: 'DOUBLE', behind_schedule: 'BOOLEAN', body: 'VARCHAR'
+});
+
+CREATE TABLE experiments AS
+SELECT *
+FROM read_json('output/experiments/*.json', columns={
+    id: 'UUID', catalog: 'VARCHAR', benchmark: 'INTEGER', threads: 'INTEGER', agents: 'VARCHAR[]', seed: 'UBIGINT',
+    start_timestamp: 'TIMESTAMP', end_timestamp: 'TIMESTAMP', anchor: 'TIMESTAMP',
+    clocks: 'STRUCT(agent VARCHAR, "offset" BIGINT, uncertainty BIGINT)[]', duration: 'BIGINT', entity: 'VARCHAR',
+    parent: 'VARCHAR', populate: 'INTEGER', page_size: 'INTEGER', depth: 'INTEGER', fan_out: 'INTEGER',
+    payload: 'STRUCT(columns STRUCT(min INTEGER, max INTEGER), nesting STRUCT(min INTEGER, max INTEGER), properties STRUCT(min INTEGER, max INTEGER), property_size STRUCT(min INTEGER, max INTEGER), comment STRUCT(min INTEGER, max INTEGER), representations STRUCT(min INTEGER, max INTEGER))',
+    populate_tree: 'STRUCT(catalogs INTEGER, schemas INTEGER, tables INTEGER)', manifest: 'VARCHAR', keys: 'INTEGER',
+    distribution: 'STRUCT(type VARCHAR, theta DOUBLE, hot_ops DOUBLE, hot_keys DOUBLE)',
+    think_time: 'STRUCT(type VARCHAR, mean BIGINT, min BIGINT, max BIGINT)', rate: 'DOUBLE', summary: 'BOOLEAN',
+    body_sample: 'DOUBLE', principals: 'INTEGER', principal_roles: 'BOOLEAN'
+});
+
+-- Latency of the requests with a body by request size, in power-of-two buckets
+SELECT
+    ex.catalog,
+    ex.entity,
+    l.method,
+    POW(2, FLOOR(LOG2(l.request_size)))::BIGINT AS request_size_bucket,
+    COUNT(*) AS requests,
+    QUANTILE_CONT(l.latency_ms, 0.5) AS p50_latency_ms,
+    QUANTILE_CONT(l.latency_ms, 0.99) AS p99_latency_ms,
+    COUNT(*) FILTER (WHERE l.level = 'ERROR') / COUNT(*) AS error_rate
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+WHERE l.request_size > 0
+GROUP BY ex.catalog, ex.entity, l.method, request_size_bucket
+ORDER BY ex.catalog, ex.entity, l.method, request_size_bucket;
+
+-- Failed audits for each payload configuration
+SELECT
+    ex.catalog,
+    ex.entity,
+    ex.benchmark,
+    ex.payload,
+    COUNT(*) FILTER (WHERE l.method = 'AUDIT' AND l.level = 'ERROR') AS failed_audits,
+    AVG(l.request_size) FILTER (WHERE l.request_size > 0) AS avg_request_size
+FROM logs l
+    JOIN experiments ex ON l.experiment_id = ex.id
+GROUP BY ex.catalog, ex.entity, ex.benchmark, ex.payload
+ORDER BY ex.catalog, ex.entity, ex.benchmark;